@@ -0,0 +1,54 @@
+package forms
+
+import (
+	"html/template"
+	"strings"
+)
+
+// prefix returns the Prefix of the field, or its escaped PrefixText.
+func (f *Field) prefix() template.HTML {
+	if f.Prefix != "" {
+		return f.Prefix
+	}
+	return template.HTML(template.HTMLEscapeString(f.PrefixText))
+}
+
+// suffix returns the Suffix of the field, or its escaped SuffixText.
+func (f *Field) suffix() template.HTML {
+	if f.Suffix != "" {
+		return f.Suffix
+	}
+	return template.HTML(template.HTMLEscapeString(f.SuffixText))
+}
+
+// wrapAddons wraps the rendered input in an input group holding the prefix and suffix of the field,
+// using the classes of Bootstrap's input groups:
+//
+//	<span class="input-group"><span class="input-group-text">€</span><input ...></span>
+//
+// The group is a span rather than a div, as AsP wraps fields in paragraphs.
+// Fields without add-ons, and hidden, checkbox and radio fields, are returned unchanged.
+func (f *Field) wrapAddons(html template.HTML) template.HTML {
+	var prefix, suffix = f.prefix(), f.suffix()
+	if prefix == "" && suffix == "" || f.IsHidden() || f.isCheckable() {
+		return html
+	}
+	var newline = f.newline()
+	var b strings.Builder
+	b.WriteString(`<span class="input-group">`)
+	writeAddon(&b, prefix)
+	b.WriteString(strings.TrimSuffix(string(html), newline))
+	writeAddon(&b, suffix)
+	b.WriteString(`</span>`)
+	b.WriteString(newline)
+	return template.HTML(b.String())
+}
+
+func writeAddon(b *strings.Builder, addon template.HTML) {
+	if addon == "" {
+		return
+	}
+	b.WriteString(`<span class="input-group-text">`)
+	b.WriteString(string(addon))
+	b.WriteString(`</span>`)
+}
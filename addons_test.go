@@ -0,0 +1,38 @@
+package forms_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestAddons(t *testing.T) {
+	var f = &forms.Form{DefaultWrapperClass: "mb-3"}
+	var price = f.DecimalField("price", "price", "form-control", "", "9.99", 10, 2)
+	price.PrefixText = "€"
+	var expected = "<p class=\"mb-3\"><label for=\"price\">Price</label>\r\n" +
+		"<span class=\"input-group\"><span class=\"input-group-text\">€</span>" +
+		"<input type=\"text\" id=\"price\" name=\"price\" value=\"9.99\" class=\"form-control\" inputmode=\"decimal\" pattern=\"[+\\-]?[0-9]+([.][0-9]{1,2})?\">" +
+		"</span>\r\n</p>"
+	if got := string(f.AsP()); got != expected {
+		t.Errorf("Unexpected rendering of the price field:\n%s\nexpected:\n%s", got, expected)
+	}
+
+	var search = forms.New("q", forms.WithType(forms.TypeText))
+	search.Prefix = `<svg class="icon"></svg>`
+	search.SuffixText = "<kbd>"
+	var html = search.Field().String()
+	if !strings.HasPrefix(html, `<span class="input-group"><span class="input-group-text"><svg class="icon"></svg></span><input`) ||
+		!strings.HasSuffix(html, `<span class="input-group-text">&lt;kbd&gt;</span></span>`+"\r\n") {
+		t.Errorf("Expected the HTML prefix and the escaped text suffix, got %s", html)
+	}
+
+	for _, typ := range []string{forms.TypeHidden, forms.TypeCheck, forms.TypeRadio} {
+		var field = forms.New("x", forms.WithType(typ))
+		field.PrefixText = "€"
+		if html := field.Field().String(); strings.Contains(html, "input-group") {
+			t.Errorf("Expected no add-ons for %s fields, got %s", typ, html)
+		}
+	}
+}
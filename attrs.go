@@ -0,0 +1,83 @@
+package forms
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+)
+
+// attrWriter is written to by writeAttr and writeCanonical, e.g. a bytes.Buffer or a strings.Builder.
+type attrWriter interface {
+	io.Writer
+	io.StringWriter
+}
+
+// An attribute of a rendered element, boolean attributes are written without a value.
+type attr struct {
+	name    string
+	value   string
+	boolean bool
+}
+
+// attrRank returns the position of an attribute in the canonical ordering.
+//
+// The canonical ordering is type, id, name and value, followed by all other attributes in alphabetical order.
+func attrRank(name string) int {
+	switch name {
+	case "type":
+		return 0
+	case "id":
+		return 1
+	case "name":
+		return 2
+	case "value":
+		return 3
+	}
+	return 4
+}
+
+func attrLess(a, b attr) bool {
+	var ra, rb = attrRank(a.name), attrRank(b.name)
+	if ra != rb {
+		return ra < rb
+	}
+	return a.name < b.name
+}
+
+// writeCanonical sorts the attributes in canonical order and writes them, each preceded by a space.
+//
+// Attributes with equal names keep their relative order.
+func writeCanonical(b attrWriter, attrs []attr) {
+	// Insertion sort, elements have few attributes and this avoids allocating.
+	for i := 1; i < len(attrs); i++ {
+		for j := i; j > 0 && attrLess(attrs[j], attrs[j-1]); j-- {
+			attrs[j], attrs[j-1] = attrs[j-1], attrs[j]
+		}
+	}
+	for _, a := range attrs {
+		if a.boolean {
+			b.WriteString(` `)
+			b.WriteString(a.name)
+			continue
+		}
+		writeAttr(b, a.name, a.value)
+	}
+}
+
+// BuildAttrs renders attributes in the canonical order used by all fields:
+// type, id, name and value, followed by all other attributes in alphabetical order.
+//
+// Names and values are escaped, boolean attributes are written without a value.
+// Each attribute is preceded by a space, e.g. ` type="text" id="name" required`.
+func BuildAttrs(attrs map[string]string, boolAttrs []string) string {
+	var list = make([]attr, 0, len(attrs)+len(boolAttrs))
+	for k, v := range attrs {
+		list = append(list, attr{name: template.HTMLEscapeString(k), value: v})
+	}
+	for _, k := range boolAttrs {
+		list = append(list, attr{name: template.HTMLEscapeString(k), boolean: true})
+	}
+	var b bytes.Buffer
+	writeCanonical(&b, list)
+	return b.String()
+}
@@ -0,0 +1,89 @@
+package forms
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Common autocomplete tokens, see the HTML specification for the full list.
+const (
+	AutocompleteOff             = "off"
+	AutocompleteOn              = "on"
+	AutocompleteName            = "name"
+	AutocompleteGivenName       = "given-name"
+	AutocompleteFamilyName      = "family-name"
+	AutocompleteNickname        = "nickname"
+	AutocompleteUsername        = "username"
+	AutocompleteEmail           = "email"
+	AutocompleteTel             = "tel"
+	AutocompleteNewPassword     = "new-password"
+	AutocompleteCurrentPassword = "current-password"
+	AutocompleteOneTimeCode     = "one-time-code"
+	AutocompleteOrganization    = "organization"
+	AutocompleteStreetAddress   = "street-address"
+	AutocompletePostalCode      = "postal-code"
+	AutocompleteCountry         = "country"
+	AutocompleteBirthday        = "bday"
+	AutocompleteURL             = "url"
+	AutocompleteCCNumber        = "cc-number"
+	AutocompleteCCExpiry        = "cc-exp"
+	AutocompleteCCCSC           = "cc-csc"
+)
+
+// The autofill field names of the HTML specification.
+var autocompleteFields = map[string]bool{
+	"name": true, "honorific-prefix": true, "given-name": true, "additional-name": true, "family-name": true,
+	"honorific-suffix": true, "nickname": true, "username": true, "new-password": true, "current-password": true,
+	"one-time-code": true, "organization-title": true, "organization": true, "street-address": true,
+	"address-line1": true, "address-line2": true, "address-line3": true, "address-level4": true,
+	"address-level3": true, "address-level2": true, "address-level1": true, "country": true, "country-name": true,
+	"postal-code": true, "cc-name": true, "cc-given-name": true, "cc-additional-name": true, "cc-family-name": true,
+	"cc-number": true, "cc-exp": true, "cc-exp-month": true, "cc-exp-year": true, "cc-csc": true, "cc-type": true,
+	"transaction-currency": true, "transaction-amount": true, "language": true, "bday": true, "bday-day": true,
+	"bday-month": true, "bday-year": true, "sex": true, "url": true, "photo": true,
+	"tel": true, "tel-country-code": true, "tel-national": true, "tel-area-code": true, "tel-local": true,
+	"tel-local-prefix": true, "tel-local-suffix": true, "tel-extension": true, "email": true, "impp": true,
+}
+
+// validAutocomplete reports whether value is "on", "off", or an optional section, address type and contact type,
+// followed by an autofill field name and an optional "webauthn".
+func validAutocomplete(value string) bool {
+	var tokens = strings.Fields(strings.ToLower(value))
+	if len(tokens) == 1 && (tokens[0] == AutocompleteOn || tokens[0] == AutocompleteOff) {
+		return true
+	}
+	if len(tokens) > 0 && tokens[len(tokens)-1] == "webauthn" {
+		tokens = tokens[:len(tokens)-1]
+	}
+	if len(tokens) == 0 || !autocompleteFields[tokens[len(tokens)-1]] {
+		return false
+	}
+	for i, token := range tokens[:len(tokens)-1] {
+		switch {
+		case i == 0 && strings.HasPrefix(token, "section-"):
+		case token == "shipping" || token == "billing":
+		case token == "home" || token == "work" || token == "mobile" || token == "fax" || token == "pager":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// CheckAutocomplete reports the fields whose autocomplete attribute browsers would ignore, e.g. because of a typo.
+//
+// It is meant to be called from tests, like go vet.
+func (f *Form) CheckAutocomplete() []string {
+	if f == nil {
+		return nil
+	}
+	var problems []string
+	for _, field := range f.Fields {
+		var fld, ok = asField(field)
+		if !ok || fld.Autocomplete == "" || validAutocomplete(fld.Autocomplete) {
+			continue
+		}
+		problems = append(problems, fmt.Sprintf("field %s: invalid autocomplete value %q", fld.Name, fld.Autocomplete))
+	}
+	return problems
+}
@@ -0,0 +1,36 @@
+package forms_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestAutocomplete(t *testing.T) {
+	var f = forms.Form{}
+	f.AddPasswordField("password")
+	f.AddPasswordField("new_password", forms.WithAutocomplete(forms.AutocompleteNewPassword))
+	f.PasswordField("confirm", "", "", "", "")
+	f.AddTextField("email", forms.WithAutocomplete("emial"))
+	f.AddTextField("shipping_zip", forms.WithAutocomplete("section-a shipping postal-code"))
+	f.AddTextField("phone", forms.WithAutocomplete("work tel"))
+	f.AddTextField("code", forms.WithAutocomplete("off"))
+	f.AddTextField("key", forms.WithAutocomplete("username webauthn"))
+	f.AddTextField("bad_order", forms.WithAutocomplete("postal-code shipping"))
+
+	for name, expected := range map[string]string{"password": "current-password", "new_password": "new-password", "confirm": "current-password"} {
+		if got := f.Field(name).Field().String(); !strings.Contains(got, `autocomplete="`+expected+`"`) {
+			t.Errorf("Expected %s to autocomplete %q, got %s", name, expected, got)
+		}
+	}
+
+	var expected = []string{
+		`field email: invalid autocomplete value "emial"`,
+		`field bad_order: invalid autocomplete value "postal-code shipping"`,
+	}
+	if got := f.CheckAutocomplete(); fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
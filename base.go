@@ -0,0 +1,40 @@
+package forms
+
+// BaseField is embedded by custom field types, e.g. a captcha or a map picker,
+// to implement FormElement without writing every method by hand.
+//
+// All methods are provided by the embedded Field,
+// a custom field usually only overrides Field and Validate:
+//
+//	type Captcha struct {
+//		forms.BaseField
+//		Question string
+//	}
+//
+//	func (c *Captcha) Field() forms.ElementInterface { ... }
+//	func (c *Captcha) Validate() error { ... }
+//
+// The default implementations stay available, e.g. c.BaseField.Field().
+// The form treats the embedded Field like any other *Field,
+// so form-level behavior such as read-only checks, error classes and labels keeps working.
+//
+// BaseField is an alias of Field: embedding Field itself would name the embedded field "Field",
+// hiding the Field method behind it.
+type BaseField = Field
+
+// fielder is implemented by *Field, and by every type embedding a Field or BaseField.
+type fielder interface {
+	baseField() *Field
+}
+
+func (f *Field) baseField() *Field {
+	return f
+}
+
+// asField returns the Field backing a form element, if it has one.
+func asField(field FormElement) (*Field, bool) {
+	if f, ok := field.(fielder); ok {
+		return f.baseField(), true
+	}
+	return nil, false
+}
@@ -0,0 +1,40 @@
+package forms_test
+
+import (
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestBaseFieldRendering(t *testing.T) {
+	var captcha = &Captcha{Question: "2 + 3 =", Answer: "5"}
+	captcha.Name = "captcha"
+	captcha.LabelText = "Captcha"
+
+	var f = forms.Form{ErrorWrapperClass: "has-error"}
+	f.AddFields(captcha)
+	if err := f.ModifyField("captcha", func(fld *forms.Field) { fld.LabelClass = "label" }); err != nil {
+		t.Fatal(err)
+	}
+	if f.FillValues(map[string][]string{"captcha": {"4"}}) {
+		t.Fatalf("Expected the overridden Validate to be used")
+	}
+	var expected = "<ul class=\"errorlist\">\r\n<li>wrong answer</li>\r\n</ul>\r\n" +
+		"<p class=\"has-error\"><label class=\"label\" for=\"captcha\">Captcha</label>\r\n" +
+		"<span>2 + 3 =</span><input type=\"text\" name=\"captcha\"></p>"
+	if got := string(f.AsP()); got != expected {
+		t.Errorf("Expected \n%q\ngot \n%q", expected, got)
+	}
+}
+
+func TestBaseFieldDefaults(t *testing.T) {
+	type plain struct {
+		forms.BaseField
+	}
+	var field = &plain{}
+	field.Name = "plain"
+	var element forms.FormElement = field
+	if got := element.Field().String(); got != "<input type=\"text\" id=\"plain\" name=\"plain\">\r\n" {
+		t.Errorf("Expected the default rendering, got %s", got)
+	}
+}
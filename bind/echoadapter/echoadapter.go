@@ -0,0 +1,34 @@
+// Package echoadapter fills forms from echo handlers.
+//
+// It is a separate module, so the forms package does not depend on echo.
+package echoadapter
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Nigel2392/forms"
+	"github.com/labstack/echo/v4"
+)
+
+// Bind fills the form from the request of the echo context, see forms.Form.Fill.
+func Bind(c echo.Context, f *forms.Form) bool {
+	return f.FillRequest(c.Request())
+}
+
+// Error translates the errors of a form which did not pass Bind into an *echo.HTTPError.
+//
+// Submissions which could not be read result in 400 Bad Request,
+// a failed CSRF verification in 403 Forbidden and validation errors in 422 Unprocessable Entity.
+// Error returns nil if the form has no errors.
+func Error(f *forms.Form) error {
+	switch {
+	case errors.Is(f.FillError, forms.ErrCSRF):
+		return echo.NewHTTPError(http.StatusForbidden, f.FillError.Error()).SetInternal(f.FillError)
+	case f.FillError != nil:
+		return echo.NewHTTPError(http.StatusBadRequest, f.FillError.Error()).SetInternal(f.FillError)
+	case f.Errors.HasErrors():
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, f.Errors).SetInternal(f.Errors)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package echoadapter_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+	"github.com/Nigel2392/forms/bind/echoadapter"
+	"github.com/labstack/echo/v4"
+)
+
+func newContext(values url.Values) echo.Context {
+	var r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(values.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return echo.New().NewContext(r, httptest.NewRecorder())
+}
+
+func TestBind(t *testing.T) {
+	var f = &forms.Form{}
+	f.AddTextField("name", forms.WithRequired())
+	if !echoadapter.Bind(newContext(url.Values{"name": {"John"}}), f) || f.Get("name").String() != "John" {
+		t.Fatalf("Expected the form to be filled, got %v", f.Errors)
+	}
+	if err := echoadapter.Error(f); err != nil {
+		t.Errorf("Expected no error for a valid form, got %v", err)
+	}
+
+	f = &forms.Form{}
+	f.AddTextField("name", forms.WithRequired())
+	if echoadapter.Bind(newContext(url.Values{}), f) {
+		t.Fatalf("Expected the form to be invalid")
+	}
+	var httpErr *echo.HTTPError
+	if err := echoadapter.Error(f); !errors.As(err, &httpErr) || httpErr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected a 422 echo error, got %v", err)
+	}
+
+	f = &forms.Form{}
+	f.AddTextField("name")
+	f.CSRFToken("token")
+	echoadapter.Bind(newContext(url.Values{"name": {"John"}}), f)
+	if err := echoadapter.Error(f); !errors.As(err, &httpErr) || httpErr.Code != http.StatusForbidden {
+		t.Errorf("Expected a 403 echo error, got %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+// Package ginadapter fills forms from gin handlers.
+//
+// It is a separate module, so the forms package does not depend on gin.
+package ginadapter
+
+import (
+	"github.com/Nigel2392/forms"
+	"github.com/gin-gonic/gin"
+)
+
+// Bind fills the form from the request of the gin context, see forms.Form.Fill.
+//
+// If the form is not valid, the error returned by Error is attached to the context with c.Error.
+func Bind(c *gin.Context, f *forms.Form) bool {
+	if f.FillRequest(c.Request) {
+		return true
+	}
+	if err := Error(f); err != nil {
+		c.Error(err)
+	}
+	return false
+}
+
+// Error translates the errors of a form which did not pass Bind into a *gin.Error.
+//
+// Submissions which could not be read, or failed CSRF verification, are of type gin.ErrorTypePrivate
+// with the forms.FillError as Err, validation errors are of type gin.ErrorTypeBind with the form errors as Err.
+// Error returns nil if the form has no errors.
+func Error(f *forms.Form) *gin.Error {
+	switch {
+	case f.FillError != nil:
+		return &gin.Error{Err: f.FillError, Type: gin.ErrorTypePrivate}
+	case f.Errors.HasErrors():
+		return &gin.Error{Err: f.Errors, Type: gin.ErrorTypeBind}
+	}
+	return nil
+}
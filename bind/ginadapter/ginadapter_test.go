@@ -0,0 +1,50 @@
+package ginadapter_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+	"github.com/Nigel2392/forms/bind/ginadapter"
+	"github.com/gin-gonic/gin"
+)
+
+func newContext(values url.Values) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	var c, _ = gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(values.Encode()))
+	c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return c
+}
+
+func TestBind(t *testing.T) {
+	var f = &forms.Form{}
+	f.AddTextField("name", forms.WithRequired())
+	var c = newContext(url.Values{"name": {"John"}})
+	if !ginadapter.Bind(c, f) || f.Get("name").String() != "John" || len(c.Errors) != 0 {
+		t.Fatalf("Expected the form to be filled, got %v", f.Errors)
+	}
+
+	f = &forms.Form{}
+	f.AddTextField("name", forms.WithRequired())
+	c = newContext(url.Values{})
+	if ginadapter.Bind(c, f) {
+		t.Fatalf("Expected the form to be invalid")
+	}
+	if len(c.Errors) != 1 || !c.Errors[0].IsType(gin.ErrorTypeBind) {
+		t.Errorf("Expected a bind error on the context, got %v", c.Errors)
+	}
+
+	f = &forms.Form{}
+	f.AddTextField("name")
+	f.CSRFToken("token")
+	c = newContext(url.Values{"name": {"John"}})
+	ginadapter.Bind(c, f)
+	if len(c.Errors) != 1 || !errors.Is(c.Errors[0].Err, forms.ErrCSRF) {
+		t.Errorf("Expected the CSRF error on the context, got %v", c.Errors)
+	}
+}
@@ -0,0 +1,81 @@
+package forms
+
+import (
+	"context"
+	"encoding/json"
+	"html/template"
+	"net/http"
+)
+
+// A Binder binds a request to a form and validates it, the part of a form request handlers depend on.
+//
+// GetErrors returns the validation errors, as Form already has an Errors field.
+type Binder interface {
+	FillRequest(r *http.Request) bool
+	Validate() bool
+	GetErrors() FormErrors
+}
+
+// A Renderer renders a form as HTML.
+type Renderer interface {
+	HTML() template.HTML
+}
+
+var (
+	_ Binder   = (*Form)(nil)
+	_ Renderer = (*Form)(nil)
+)
+
+// GetErrors returns the errors of the form.
+func (f *Form) GetErrors() FormErrors {
+	if f == nil {
+		return nil
+	}
+	return f.Errors
+}
+
+// HTML renders the form as paragraphs, see AsP.
+func (f *Form) HTML() template.HTML {
+	if f == nil {
+		return ""
+	}
+	return f.AsP()
+}
+
+// RequireValid binds every request to the binder before calling next.
+// Requests which are not valid are answered with 422 Unprocessable Entity and the errors as JSON.
+//
+// The binder is shared by all requests: a form is not safe for concurrent use,
+// so the handler must not serve requests concurrently. Use RequireValidFunc to bind every request to its own form.
+func RequireValid(b Binder, next http.Handler) http.Handler {
+	return RequireValidFunc(func(*http.Request) Binder { return b }, next)
+}
+
+type binderKey struct{}
+
+// RequireValidFunc is RequireValid, binding every request to a new binder created by newBinder,
+// e.g. a form built for the request.
+//
+// The binder is stored in the context of the request passed to next, see BinderFrom.
+func RequireValidFunc(newBinder func(r *http.Request) Binder, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var b = newBinder(r)
+		if b.FillRequest(r) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), binderKey{}, b)))
+			return
+		}
+		var errs = b.GetErrors()
+		if errs == nil {
+			errs = FormErrors{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(errs)
+	})
+}
+
+// BinderFrom returns the binder stored in ctx by RequireValid or RequireValidFunc, if any.
+func BinderFrom(ctx context.Context) (Binder, bool) {
+	var b, ok = ctx.Value(binderKey{}).(Binder)
+	return b, ok
+}
@@ -0,0 +1,96 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestRequireValid(t *testing.T) {
+	var f = &forms.Form{}
+	f.TextField("name", "name", "", "", "").Required = true
+
+	var called bool
+	var h = forms.RequireValid(f, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	var serve = func(body string) *httptest.ResponseRecorder {
+		var r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		var w = httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	var w = serve("")
+	if called || w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected an invalid request to be answered with 422, got %d (called: %v)", w.Code, called)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected a JSON response, got %q", ct)
+	}
+	var errs forms.FormErrors
+	if err := json.NewDecoder(w.Body).Decode(&errs); err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 || errs[0].Name != "name" {
+		t.Errorf("Expected an error for name, got %v", errs)
+	}
+
+	w = serve("")
+	errs = nil
+	if err := json.NewDecoder(w.Body).Decode(&errs); err != nil || len(errs) != 1 {
+		t.Errorf("Expected the errors of the previous request to be reset, got %v (%v)", errs, err)
+	}
+
+	w = serve("name=John")
+	if !called || w.Code != http.StatusNoContent {
+		t.Errorf("Expected a valid request to reach the handler, got %d (called: %v)", w.Code, called)
+	}
+	if len(f.Errors) != 0 || f.Field("name").HasError() {
+		t.Errorf("Expected a valid request to leave no errors, got %v", f.Errors)
+	}
+
+	f.FillRequest(httptest.NewRequest(http.MethodGet, "/", nil))
+	if f.Validate() || len(f.Errors) != 1 || len(f.Field("name").(*forms.Field).FormErrors) != 1 {
+		t.Errorf("Expected Validate to replace the errors of FillRequest, got %v", f.Errors)
+	}
+	if f.HTML() != f.AsP() {
+		t.Errorf("Expected HTML to render the form as paragraphs")
+	}
+}
+
+func TestRequireValidFunc(t *testing.T) {
+	var created = make(map[*forms.Form]bool)
+	var h = forms.RequireValidFunc(func(r *http.Request) forms.Binder {
+		var f = &forms.Form{}
+		f.TextField("name", "name", "", "", "").Required = true
+		created[f] = true
+		return f
+	}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var b, ok = forms.BinderFrom(r.Context())
+		if !ok {
+			t.Fatal("Expected the binder to be stored in the request context")
+		}
+		w.Write([]byte(b.(*forms.Form).Get("name").String()))
+	}))
+
+	for _, name := range []string{"John", "Jane"} {
+		var r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name="+name))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		var w = httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Body.String() != name {
+			t.Errorf("Expected the handler to receive the request's form, got %q", w.Body.String())
+		}
+	}
+	if len(created) != 2 {
+		t.Errorf("Expected a form per request, got %d", len(created))
+	}
+}
@@ -0,0 +1,108 @@
+package forms
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Nigel2392/forms/validators"
+)
+
+// Required is shorthand for WithRequired, for use with the FormBuilder.
+func Required() FieldOption {
+	return WithRequired()
+}
+
+// MaxLen limits the value to at most max characters with validators.MaxLength,
+// which is rendered as the maxlength attribute.
+func MaxLen(max int) FieldOption {
+	return WithValidators(validators.MaxLength(max))
+}
+
+// MinLen requires the value to be at least min characters long with validators.MinLength,
+// which is rendered as the minlength attribute.
+func MinLen(min int) FieldOption {
+	return WithValidators(validators.MinLength(min))
+}
+
+// FormBuilder builds a form through chained calls.
+//
+// Errors, such as duplicate field names, are recorded and returned by Build.
+type FormBuilder struct {
+	form *Form
+	errs []error
+}
+
+// NewForm starts building a new form.
+func NewForm() *FormBuilder {
+	return &FormBuilder{form: &Form{}}
+}
+
+// Add adds any form element to the form.
+func (b *FormBuilder) Add(field FormElement) *FormBuilder {
+	if err := b.form.AddFieldsStrict(field); err != nil {
+		b.errs = append(b.errs, err)
+	}
+	return b
+}
+
+func (b *FormBuilder) add(typ string, name string, opts []FieldOption) *FormBuilder {
+	return b.Add(New(name, append([]FieldOption{WithType(typ)}, opts...)...))
+}
+
+func (b *FormBuilder) Text(name string, opts ...FieldOption) *FormBuilder {
+	return b.add(TypeText, name, opts)
+}
+
+func (b *FormBuilder) Password(name string, opts ...FieldOption) *FormBuilder {
+	return b.add(TypePassword, name, append([]FieldOption{WithAutocomplete(AutocompleteCurrentPassword)}, opts...))
+}
+
+func (b *FormBuilder) Email(name string, opts ...FieldOption) *FormBuilder {
+	return b.Add(New(name, append([]FieldOption{WithType(TypeEmail), WithValidators(validators.Email)}, opts...)...))
+}
+
+func (b *FormBuilder) Number(name string, opts ...FieldOption) *FormBuilder {
+	return b.add(TypeNumber, name, opts)
+}
+
+func (b *FormBuilder) Hidden(name string, opts ...FieldOption) *FormBuilder {
+	return b.add(TypeHidden, name, opts)
+}
+
+func (b *FormBuilder) TextArea(name string, opts ...FieldOption) *FormBuilder {
+	return b.add(TypeTextArea, name, opts)
+}
+
+func (b *FormBuilder) Select(name string, options []Option, opts ...FieldOption) *FormBuilder {
+	return b.add(TypeSelect, name, append([]FieldOption{WithOptions(options)}, opts...))
+}
+
+func (b *FormBuilder) Checkbox(name string, opts ...FieldOption) *FormBuilder {
+	return b.add(TypeCheck, name, opts)
+}
+
+func (b *FormBuilder) File(name string, opts ...FieldOption) *FormBuilder {
+	return b.add(TypeFile, name, opts)
+}
+
+// Submit adds a submit button named "submit" with the given text.
+func (b *FormBuilder) Submit(text string, opts ...FieldOption) *FormBuilder {
+	var field = New("submit", append([]FieldOption{WithType(TypeSubmit)}, opts...)...)
+	field.ButtonText = text
+	return b.Add(field)
+}
+
+// CSRF adds the hidden csrf_token field.
+func (b *FormBuilder) CSRF(token string) *FormBuilder {
+	if b.form.HasField(CSRFFieldName) {
+		b.errs = append(b.errs, fmt.Errorf("duplicate field name %q", CSRFFieldName))
+		return b
+	}
+	b.form.CSRFToken(token)
+	return b
+}
+
+// Build returns the form, along with any errors recorded while building it.
+func (b *FormBuilder) Build() (*Form, error) {
+	return b.form, errors.Join(b.errs...)
+}
@@ -0,0 +1,46 @@
+package forms_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+	"github.com/Nigel2392/forms/validators"
+)
+
+func TestFormBuilder(t *testing.T) {
+	built, err := forms.NewForm().
+		Text("username", forms.Required(), forms.MaxLen(150)).
+		Password("password", forms.MinLen(8)).
+		Submit("Log in").
+		CSRF("token").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var manual = &forms.Form{}
+	var username = manual.TextField("username", "", "", "", "")
+	username.Required = true
+	username.Validators = append(username.Validators, validators.MaxLength(150))
+	var password = manual.PasswordField("password", "", "", "", "")
+	password.Validators = append(password.Validators, validators.MinLength(8))
+	manual.SubmitButton("submit", "", "", "").ButtonText = "Log in"
+	manual.CSRFToken("token")
+
+	if built.AsP() != manual.AsP() {
+		t.Errorf("Expected built and manual forms to render the same, got \n%s\n%s", built.AsP(), manual.AsP())
+	}
+	if html := string(built.AsP()); !strings.Contains(html, `maxlength="150"`) || !strings.Contains(html, `minlength="8"`) || strings.Contains(html, `max="`) {
+		t.Errorf("Expected MaxLen and MinLen to limit the length of the values, got %s", html)
+	}
+	if built.FillValues(url.Values{"username": {strings.Repeat("a", 151)}, "password": {"hunter2"}}) || len(built.Errors) != 2 {
+		t.Errorf("Expected the username and password lengths to be validated, got %v", built.Errors)
+	}
+
+	_, err = forms.NewForm().Text("name").Text("name").Build()
+	if err == nil || !strings.Contains(err.Error(), `duplicate field name "name"`) {
+		t.Errorf("Expected a duplicate field name error, got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package forms
+
+import (
+	"strconv"
+
+	"github.com/Nigel2392/forms/validators"
+)
+
+// ParseByteSize parses a size such as "512MB", "2GiB" or a bare number of bytes.
+//
+// Both SI (KB, MB, ...) and binary (KiB, MiB, ...) suffixes are supported, matched case-insensitively.
+func ParseByteSize(s string) (int64, error) {
+	return validators.ParseByteSize(s)
+}
+
+// NormalizeByteSize is a SubmitNormalizer which converts a size such as "512MB" into a plain byte count,
+// so the value can be scanned into integers.
+func NormalizeByteSize(s string) (string, error) {
+	if s == "" {
+		return s, nil
+	}
+	var n, err = ParseByteSize(s)
+	if err != nil {
+		return s, err
+	}
+	return strconv.FormatInt(n, 10), nil
+}
+
+// FormatByteSize is a DisplayFormatter which renders a plain byte count in the largest sensible unit, e.g. "2GiB".
+//
+// Values which are not plain byte counts are returned unchanged.
+func FormatByteSize(s string) string {
+	var n, err = strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return s
+	}
+	return validators.FormatByteSize(n)
+}
+
+// ByteSizeField adds a field for sizes such as "512MB" or "2GiB", of at least min and at most max bytes.
+// A max of 0 or less means no limit.
+//
+// Submitted values are normalized to a plain byte count, and rendered back in the largest sensible unit.
+func (f *Form) ByteSizeField(name string, min, max int64) *Field {
+	var field = New(name)
+	field.SubmitNormalizer = NormalizeByteSize
+	field.DisplayFormatter = FormatByteSize
+	field.Validators = validators.New(
+		validators.ByteSize(min, max),
+	)
+	f.AddFields(field)
+	return field
+}
@@ -0,0 +1,67 @@
+package forms_test
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestByteSize(t *testing.T) {
+	var tests = []struct {
+		input    string
+		expected int64
+		err      bool
+	}{
+		{input: "0", expected: 0},
+		{input: "1024", expected: 1024},
+		{input: "512B", expected: 512},
+		{input: "1KB", expected: 1000},
+		{input: "1KiB", expected: 1024},
+		{input: "512MB", expected: 512_000_000},
+		{input: "512mb", expected: 512_000_000},
+		{input: "2GiB", expected: 2 << 30},
+		{input: "2gib", expected: 2 << 30},
+		{input: "1.5 GB", expected: 1_500_000_000},
+		{input: "10M", expected: 10_000_000},
+		{input: "3TiB", expected: 3 << 40},
+		{input: "1.5B", err: true},
+		{input: "-1MB", err: true},
+		{input: "12 parsecs", err: true},
+		{input: "MB", err: true},
+		{input: "99999PiB", err: true},
+	}
+	for _, test := range tests {
+		var n, err = forms.ParseByteSize(test.input)
+		if test.err && err == nil {
+			t.Errorf("Expected %q to fail, got %d", test.input, n)
+		}
+		if !test.err && (err != nil || n != test.expected) {
+			t.Errorf("Expected %q to be %d bytes, got %d (%v)", test.input, test.expected, n, err)
+		}
+	}
+
+	for input, rendered := range map[string]string{"2GiB": "2GiB", "512MB": "512MB", "1536": "1.5KiB", "1.5GB": "1.5GB", "1001": "1001B"} {
+		var f = forms.Form{}
+		var field = f.ByteSizeField("limit", 1, 4<<30)
+		if !f.FillValues(url.Values{"limit": {input}}) {
+			t.Errorf("Expected %s to be valid, got %v", input, f.Errors)
+			continue
+		}
+		var n int64
+		if err := f.Scan([]string{"limit"}, &n); err != nil || strconv.FormatInt(n, 10) != field.Value().String() {
+			t.Errorf("Expected %s to scan as a byte count, got %d (%v)", input, n, err)
+		}
+		if !strings.Contains(field.Field().String(), `value="`+rendered+`"`) {
+			t.Errorf("Expected %s to be rendered as %s, got %s", input, rendered, field.Field().String())
+		}
+	}
+
+	var f = forms.Form{}
+	f.ByteSizeField("limit", 1, 4<<30)
+	if f.FillValues(url.Values{"limit": {"5GiB"}}) || !strings.Contains(f.Errors.Error(), "at most 4GiB") {
+		t.Errorf("Expected a size over the maximum to fail, got %v", f.Errors)
+	}
+}
@@ -0,0 +1,192 @@
+package forms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrCaptcha is wrapped by the error of a CaptchaField whose token could not be verified.
+var ErrCaptcha = errors.New("captcha verification failed")
+
+// A CaptchaProvider renders a captcha widget and verifies the token it submits.
+type CaptchaProvider interface {
+	// Render the markup of the widget, usually a script tag and a widget div.
+	Render(siteKey string) template.HTML
+	// Verify the submitted token, remoteIP is empty when unknown.
+	Verify(ctx context.Context, token, remoteIP string) error
+}
+
+// A CaptchaField renders the widget of its Provider, and verifies the submitted token during validation.
+//
+// The name of the field is the name of the token the widget submits,
+// e.g. "g-recaptcha-response" or "h-captcha-response".
+type CaptchaField struct {
+	BaseField
+	Provider CaptchaProvider
+	SiteKey  string
+
+	ctx      context.Context
+	remoteIP string
+}
+
+// NewCaptchaField creates a captcha field for the token submitted as name.
+func NewCaptchaField(name string, provider CaptchaProvider, siteKey string) *CaptchaField {
+	var c = &CaptchaField{Provider: provider, SiteKey: siteKey}
+	c.Name = name
+	c.Type = "captcha"
+	return c
+}
+
+// Field renders the markup of the provider.
+func (c *CaptchaField) Field() ElementInterface {
+	return Element(c.Provider.Render(c.SiteKey))
+}
+
+// Validate verifies the submitted token with the provider,
+// passing the client IP of the request the form was filled from.
+func (c *CaptchaField) Validate() error {
+	var token = c.Value().String()
+	if token == "" {
+		return ErrCaptcha
+	}
+	var ctx = c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := c.Provider.Verify(ctx, token, c.remoteIP); err != nil {
+		if errors.Is(err, ErrCaptcha) {
+			return err
+		}
+		return fmt.Errorf("%w: %w", ErrCaptcha, err)
+	}
+	return nil
+}
+
+func (c *CaptchaField) setRequest(r *http.Request, remoteIP string) {
+	c.ctx = r.Context()
+	c.remoteIP = remoteIP
+}
+
+// requestBound is implemented by fields which need the request the form is filled from.
+type requestBound interface {
+	setRequest(r *http.Request, remoteIP string)
+}
+
+// bindRequest passes the request and the client IP to the fields which need them.
+func (f *Form) bindRequest(r *http.Request) {
+	var ip = f.clientIP(r)
+	for _, field := range f.Fields {
+		if b, ok := field.(requestBound); ok {
+			b.setRequest(r, ip)
+		}
+	}
+}
+
+// RemoteIP returns the host of the request's RemoteAddr.
+//
+// Forwarding headers are not trusted, set Form.ClientIP to ForwardedFor when running behind a proxy.
+func RemoteIP(r *http.Request) string {
+	var host, _, err = net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+const (
+	ReCaptchaEndpoint = "https://www.google.com/recaptcha/api/siteverify"
+	HCaptchaEndpoint  = "https://api.hcaptcha.com/siteverify"
+)
+
+// CaptchaVerifier is a CaptchaProvider for reCAPTCHA and hCaptcha compatible services,
+// verifying tokens with a POST to their siteverify endpoint.
+type CaptchaVerifier struct {
+	// The siteverify endpoint, e.g. ReCaptchaEndpoint.
+	Endpoint string
+	Secret   string
+	// The script rendered before the widget, and the class of the widget div.
+	Script string
+	Class  string
+	// Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// ReCaptcha returns a verifier for Google reCAPTCHA v2, its token is submitted as "g-recaptcha-response".
+func ReCaptcha(secret string) *CaptchaVerifier {
+	return &CaptchaVerifier{
+		Endpoint: ReCaptchaEndpoint,
+		Secret:   secret,
+		Script:   "https://www.google.com/recaptcha/api.js",
+		Class:    "g-recaptcha",
+	}
+}
+
+// HCaptcha returns a verifier for hCaptcha, its token is submitted as "h-captcha-response".
+func HCaptcha(secret string) *CaptchaVerifier {
+	return &CaptchaVerifier{
+		Endpoint: HCaptchaEndpoint,
+		Secret:   secret,
+		Script:   "https://js.hcaptcha.com/1/api.js",
+		Class:    "h-captcha",
+	}
+}
+
+func (v *CaptchaVerifier) Render(siteKey string) template.HTML {
+	var b strings.Builder
+	if v.Script != "" {
+		b.WriteString(`<script src="`)
+		b.WriteString(template.HTMLEscapeString(v.Script))
+		b.WriteString(`" async defer></script>`)
+	}
+	b.WriteString(`<div class="`)
+	b.WriteString(template.HTMLEscapeString(v.Class))
+	b.WriteString(`" data-sitekey="`)
+	b.WriteString(template.HTMLEscapeString(siteKey))
+	b.WriteString(`"></div>`)
+	return template.HTML(b.String())
+}
+
+func (v *CaptchaVerifier) Verify(ctx context.Context, token, remoteIP string) error {
+	var form = url.Values{"secret": {v.Secret}, "response": {token}}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	var req, err = http.NewRequestWithContext(ctx, http.MethodPost, v.Endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	var client = v.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("siteverify responded with %s", resp.Status)
+	}
+	var result struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if !result.Success {
+		if len(result.ErrorCodes) > 0 {
+			return fmt.Errorf("%w: %s", ErrCaptcha, strings.Join(result.ErrorCodes, ", "))
+		}
+		return ErrCaptcha
+	}
+	return nil
+}
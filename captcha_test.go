@@ -0,0 +1,57 @@
+package forms_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestCaptchaField(t *testing.T) {
+	var remoteIP string
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		remoteIP = r.PostForm.Get("remoteip")
+		if r.PostForm.Get("secret") == "secret" && r.PostForm.Get("response") == "valid-token" {
+			w.Write([]byte(`{"success": true}`))
+			return
+		}
+		w.Write([]byte(`{"success": false, "error-codes": ["invalid-input-response"]}`))
+	}))
+	defer server.Close()
+
+	var verifier = forms.HCaptcha("secret")
+	verifier.Endpoint = server.URL
+
+	var f = &forms.Form{}
+	f.AddFields(forms.NewCaptchaField("h-captcha-response", verifier, "site-key"))
+	var expected = `<p><script src="https://js.hcaptcha.com/1/api.js" async defer></script><div class="h-captcha" data-sitekey="site-key"></div></p>`
+	if got := string(f.AsP()); got != expected {
+		t.Errorf("Expected \n%s\ngot \n%s", expected, got)
+	}
+
+	var r = newPostRequest(url.Values{"h-captcha-response": {"valid-token"}})
+	r.Request.RemoteAddr = "203.0.113.7:4321"
+	if !f.Fill(r) {
+		t.Errorf("Expected a valid token to pass, got %v", f.Errors)
+	}
+	if remoteIP != "203.0.113.7" {
+		t.Errorf("Expected the client IP to be passed to the verifier, got %q", remoteIP)
+	}
+
+	f.ClientIP = func(r *http.Request) string { return r.Header.Get("X-Real-IP") }
+	r = newPostRequest(url.Values{"h-captcha-response": {"forged"}})
+	r.Request.Header.Set("X-Real-IP", "198.51.100.1")
+	if f.Fill(r) {
+		t.Errorf("Expected an invalid token to fail")
+	}
+	if errs := f.Field("h-captcha-response").Errors(); len(errs) != 1 || !errors.Is(errs[0].FieldErr, forms.ErrCaptcha) {
+		t.Errorf("Expected ErrCaptcha on the field, got %v", errs)
+	}
+	if remoteIP != "198.51.100.1" {
+		t.Errorf("Expected the ClientIP hook to be used, got %q", remoteIP)
+	}
+}
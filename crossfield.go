@@ -0,0 +1,142 @@
+package forms
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// A FieldValidator validates a field against the other fields of its form,
+// e.g. an end date which must be after the start date.
+//
+// Field validators run during Form.Validate, after the field's own validation passed.
+// Errors are attached to the field carrying the validator.
+//
+// Field validators which implement FieldReferencer are also run when ValidateOnly validates a field they reference.
+type FieldValidator interface {
+	Validate(form *Form, field FormElement) error
+}
+
+// FieldValidatorFunc adapts a function to a FieldValidator.
+type FieldValidatorFunc func(form *Form, field FormElement) error
+
+func (fn FieldValidatorFunc) Validate(form *Form, field FormElement) error {
+	return fn(form, field)
+}
+
+// A FieldReferencer reports the names of the other fields a FieldValidator compares the field to.
+type FieldReferencer interface {
+	ReferencedFields() []string
+}
+
+// CompareField returns a FieldValidator comparing the field to the other field with fn,
+// which reports other as its referenced field, see FieldReferencer.
+func CompareField(other string, fn FieldValidatorFunc) FieldValidator {
+	return compareField{other: other, fn: fn}
+}
+
+type compareField struct {
+	other string
+	fn    FieldValidatorFunc
+}
+
+func (c compareField) Validate(form *Form, field FormElement) error {
+	return c.fn(form, field)
+}
+
+func (c compareField) ReferencedFields() []string {
+	return []string{c.other}
+}
+
+// referencesField reports whether any of the field's FieldValidators references the named field.
+func (f *Form) referencesField(field *Field, name string) bool {
+	for _, validator := range field.FieldValidators {
+		var r, ok = validator.(FieldReferencer)
+		if !ok {
+			continue
+		}
+		for _, other := range r.ReferencedFields() {
+			if f.nameMatches(other, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AfterField requires the value of the field to be a date after the value of the other field,
+// both are parsed with layout. Empty values are left to the Required check.
+func AfterField(other, layout string) FieldValidator {
+	return CompareField(other, func(form *Form, field FormElement) error {
+		var value, otherValue, ok = comparedValues(form, field, other)
+		if !ok {
+			return nil
+		}
+		var t, err = time.Parse(layout, value)
+		if err != nil {
+			return fmt.Errorf("%s is not a valid date", fieldLabel(field))
+		}
+		otherTime, err := time.Parse(layout, otherValue)
+		if err != nil {
+			return nil
+		}
+		if !t.After(otherTime) {
+			return fmt.Errorf("%s must be after %s", fieldLabel(field), fieldLabel(form.Field(other)))
+		}
+		return nil
+	})
+}
+
+// GreaterThanField requires the value of the field to be a number greater than the value of the other field.
+// Empty values are left to the Required check.
+func GreaterThanField(other string) FieldValidator {
+	return CompareField(other, func(form *Form, field FormElement) error {
+		var value, otherValue, ok = comparedValues(form, field, other)
+		if !ok {
+			return nil
+		}
+		var n, err = strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%s is not a valid number", fieldLabel(field))
+		}
+		otherN, err := strconv.ParseFloat(otherValue, 64)
+		if err != nil {
+			return nil
+		}
+		if n <= otherN {
+			return fmt.Errorf("%s must be greater than %s", fieldLabel(field), fieldLabel(form.Field(other)))
+		}
+		return nil
+	})
+}
+
+// DifferentFrom requires the value of the field to differ from the value of the other field,
+// e.g. a new password and the old one.
+func DifferentFrom(other string) FieldValidator {
+	return CompareField(other, func(form *Form, field FormElement) error {
+		var value, otherValue, ok = comparedValues(form, field, other)
+		if ok && value == otherValue {
+			return fmt.Errorf("%s must be different from %s", fieldLabel(field), fieldLabel(form.Field(other)))
+		}
+		return nil
+	})
+}
+
+// comparedValues returns the values of the field and the other field,
+// ok is false when either of them is missing or empty.
+func comparedValues(form *Form, field FormElement, other string) (value, otherValue string, ok bool) {
+	var otherField = form.Field(other)
+	if otherField == nil {
+		return "", "", false
+	}
+	value, otherValue = field.Value().String(), otherField.Value().String()
+	return value, otherValue, value != "" && otherValue != ""
+}
+
+// fieldLabel returns the label text of a field, or its name if it has none.
+func fieldLabel(field FormElement) string {
+	if fld, ok := asField(field); ok && fld.LabelText != "" {
+		return fld.LabelText
+	}
+	return field.GetName()
+}
@@ -0,0 +1,66 @@
+package forms_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestFieldValidators(t *testing.T) {
+	var f = forms.Form{}
+	f.AddField("start_date", forms.WithType("date"))
+	f.AddField("end_date", forms.WithType("date"), forms.WithFieldValidators(forms.AfterField("start_date", "2006-01-02")))
+	f.AddNumberField("min_price")
+	f.AddNumberField("max_price", forms.WithFieldValidators(forms.GreaterThanField("min_price")))
+	f.AddPasswordField("old_password")
+	f.AddPasswordField("new_password", forms.WithFieldValidators(forms.DifferentFrom("old_password")))
+
+	var base = url.Values{
+		"start_date": {"2023-05-01"}, "end_date": {"2023-05-02"},
+		"min_price": {"10"}, "max_price": {"20"},
+		"old_password": {"hunter2"}, "new_password": {"hunter3"},
+	}
+	var tests = []struct {
+		values   url.Values
+		field    string
+		expected string
+	}{
+		{url.Values{}, "", ""},
+		{url.Values{"end_date": {"2023-05-01"}}, "end_date", "End Date must be after Start Date"},
+		{url.Values{"end_date": {"2023-04-30"}}, "end_date", "End Date must be after Start Date"},
+		{url.Values{"end_date": {"tomorrow"}}, "end_date", "End Date is not a valid date"},
+		{url.Values{"start_date": {""}}, "", ""},
+		{url.Values{"max_price": {"10.5"}}, "", ""},
+		{url.Values{"max_price": {"10"}}, "max_price", "Max Price must be greater than Min Price"},
+		{url.Values{"max_price": {"9"}}, "max_price", "Max Price must be greater than Min Price"},
+		{url.Values{"new_password": {"hunter2"}}, "new_password", "New Password must be different from Old Password"},
+	}
+	for _, test := range tests {
+		f.Errors = nil
+		for _, field := range f.Fields {
+			field.(*forms.Field).FormErrors = nil
+		}
+		var values = url.Values{}
+		for k, v := range base {
+			values[k] = v
+		}
+		for k, v := range test.values {
+			values[k] = v
+		}
+		var valid = f.FillValues(values)
+		if test.field == "" {
+			if !valid {
+				t.Errorf("%v: expected the form to be valid, got %v", test.values, f.Errors)
+			}
+			continue
+		}
+		if valid {
+			t.Errorf("%v: expected the form to be invalid", test.values)
+			continue
+		}
+		if errs := f.Field(test.field).Errors(); len(errs) != 1 || errs[0].FieldErr.Error() != test.expected {
+			t.Errorf("%v: expected %q on %s, got %v", test.values, test.expected, test.field, errs)
+		}
+	}
+}
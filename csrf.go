@@ -0,0 +1,49 @@
+package forms
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// ErrCSRF is the FillError of a form whose CSRF token could not be verified,
+// handlers should respond with 403 Forbidden.
+var ErrCSRF = errors.New("invalid CSRF token")
+
+const (
+	// The name of the hidden field added by Form.CSRFToken.
+	CSRFFieldName = "csrf_token"
+	// The header read for the CSRF token of fetch()-based submissions, it takes precedence over the hidden field.
+	CSRFHeader = "X-CSRF-Token"
+)
+
+// ExemptCSRF disables verification of the CSRF token, e.g. for webhook-style posts.
+func (f *Form) ExemptCSRF() *Form {
+	if f == nil {
+		return nil
+	}
+	f.csrfExempt = true
+	return f
+}
+
+// verifyCSRF verifies the submitted token against the token passed to CSRFToken.
+//
+// Forms without a token, exempt forms and safe methods (GET, HEAD, OPTIONS) are not verified.
+func (f *Form) verifyCSRF(r *http.Request, posted url.Values) error {
+	if f.csrfExempt || f.csrfToken == "" {
+		return nil
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return nil
+	}
+	var token = r.Header.Get(CSRFHeader)
+	if token == "" {
+		token = posted.Get(CSRFFieldName)
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(f.csrfToken)) != 1 {
+		return ErrCSRF
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package forms_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+	"github.com/Nigel2392/router/v3/request"
+)
+
+func TestCSRFVerification(t *testing.T) {
+	var withHeader = newPostRequest(url.Values{"q": {"go"}})
+	withHeader.Request.Header.Set(forms.CSRFHeader, "secret")
+
+	var tests = []struct {
+		name    string
+		request *request.Request
+		exempt  bool
+		valid   bool
+	}{
+		{"invalid token", newPostRequest(url.Values{"q": {"go"}, "csrf_token": {"wrong"}}), false, false},
+		{"missing token", newPostRequest(url.Values{"q": {"go"}}), false, false},
+		{"hidden field token", newPostRequest(url.Values{"q": {"go"}, "csrf_token": {"secret"}}), false, true},
+		{"header token", withHeader, false, true},
+		{"safe method", request.NewRequest(nil, httptest.NewRequest(http.MethodGet, "/?q=go", nil), nil), false, true},
+		{"exempt form", newPostRequest(url.Values{"q": {"go"}}), true, true},
+	}
+	for _, test := range tests {
+		var f = &forms.Form{}
+		f.AddTextField("q")
+		f.CSRFToken("secret")
+		if test.exempt {
+			f.ExemptCSRF()
+		}
+		if valid := f.Fill(test.request); valid != test.valid {
+			t.Errorf("%s: expected valid=%t, got %t (%v)", test.name, test.valid, valid, f.FillError)
+		}
+		if !test.valid && !errors.Is(f.FillError, forms.ErrCSRF) {
+			t.Errorf("%s: expected ErrCSRF, got %v", test.name, f.FillError)
+		}
+	}
+}
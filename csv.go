@@ -0,0 +1,149 @@
+package forms
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The number of row errors a CSVField reports when MaxErrors is not set.
+const DefaultMaxCSVErrors = 10
+
+// A CSVField is a file field for an uploaded CSV file, which is parsed and validated during Validate.
+//
+// The records are available through Rows once the field is valid,
+// the uploaded file is rewound so it can still be read with GetFile.
+type CSVField struct {
+	BaseField
+	// The delimiter of the records, defaults to ','.
+	Delimiter rune
+	// The expected header row, the first record must match it when set.
+	// It is compared case insensitively, and not part of Rows.
+	Header []string
+	// The maximum number of rows, excluding the header, 0 means no limit.
+	MaxRows int
+	// Validates a row, the index counts from 0 and excludes the header.
+	ValidateRow func(index int, record []string) error
+	// The maximum number of row errors to report, defaults to DefaultMaxCSVErrors.
+	MaxErrors int
+
+	rows [][]string
+}
+
+// NewCSVField creates a file field for CSV uploads.
+func NewCSVField(name string, header ...string) *CSVField {
+	var c = &CSVField{Header: header}
+	c.Name = name
+	c.Type = TypeFile
+	c.LabelText = DefaultLabeler(name)
+	c.Attrs = map[string]string{"accept": ".csv,text/csv"}
+	return c
+}
+
+// CSVField adds a file field for CSV uploads, expecting the header row if one is given.
+func (f *Form) CSVField(name string, header ...string) *CSVField {
+	var field = NewCSVField(name, header...)
+	f.AddFields(field)
+	return field
+}
+
+// Rows returns the records of the uploaded file, after the field was validated successfully.
+func (c *CSVField) Rows() [][]string {
+	return c.rows
+}
+
+// Validate validates the field like any file field, then parses the uploaded file.
+//
+// Errors of the rows are reported separately, up to MaxErrors of them.
+func (c *CSVField) Validate() error {
+	c.rows = nil
+	if err := c.BaseField.Validate(); err != nil {
+		return err
+	}
+	var _, file = c.GetFile()
+	if file == nil {
+		return nil
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	defer file.Seek(0, io.SeekStart)
+
+	var reader = csv.NewReader(file)
+	if c.Delimiter != 0 {
+		reader.Comma = c.Delimiter
+	}
+	if len(c.Header) > 0 {
+		var header, err = reader.Read()
+		if err == io.EOF {
+			return errors.New("the file is empty")
+		}
+		if err != nil {
+			return csvError(err)
+		}
+		if !c.matchesHeader(header) {
+			return fmt.Errorf("expected the header %q, got %q", strings.Join(c.Header, ","), strings.Join(header, ","))
+		}
+	}
+
+	var maxErrors = c.MaxErrors
+	if maxErrors <= 0 {
+		maxErrors = DefaultMaxCSVErrors
+	}
+	var rows [][]string
+	var errs []error
+	for {
+		var record, err = reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Join(append(errs, csvError(err))...)
+		}
+		if c.MaxRows > 0 && len(rows) >= c.MaxRows {
+			return errors.Join(append(errs, fmt.Errorf("the file has more than %d rows", c.MaxRows))...)
+		}
+		if c.ValidateRow != nil {
+			if err := c.ValidateRow(len(rows), record); err != nil {
+				var line, _ = reader.FieldPos(0)
+				errs = append(errs, fmt.Errorf("line %d: %w", line, err))
+				if len(errs) >= maxErrors {
+					break
+				}
+			}
+		}
+		rows = append(rows, record)
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	c.rows = rows
+	return nil
+}
+
+// matchesHeader reports whether the record matches the expected header, ignoring case and a byte order mark.
+func (c *CSVField) matchesHeader(record []string) bool {
+	if len(record) != len(c.Header) {
+		return false
+	}
+	for i, name := range record {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		if !strings.EqualFold(strings.TrimSpace(name), c.Header[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// csvError describes a parse error with the line it occurred at.
+func csvError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return fmt.Errorf("line %d: %w", parseErr.Line, parseErr.Err)
+	}
+	return err
+}
@@ -0,0 +1,58 @@
+package forms_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestCSVField(t *testing.T) {
+	var content = "\ufeffName,Email\njohn,john@example.com\njane,jane@example.com\n"
+	var f = &forms.Form{}
+	var users = f.CSVField("users", "name", "email")
+	users.Required = true
+	users.ValidateRow = func(index int, record []string) error {
+		if !strings.Contains(record[1], "@") {
+			return fmt.Errorf("%q is not a valid email", record[1])
+		}
+		return nil
+	}
+	if !f.FillRequest(newUploadRequest("users", content)) {
+		t.Fatalf("Expected the CSV to be valid, got %v", f.Errors)
+	}
+	if rows := users.Rows(); len(rows) != 2 || rows[1][0] != "jane" {
+		t.Errorf("Expected the rows without the header, got %v", rows)
+	}
+	var _, file = users.GetFile()
+	if raw, _ := io.ReadAll(file); string(raw) != content {
+		t.Errorf("Expected the raw file to be rewound, got %q", raw)
+	}
+
+	if f.FillRequest(newUploadRequest("users", "username,email\njohn,john@example.com\n")) || !strings.Contains(f.Errors.Error(), `expected the header "name,email"`) {
+		t.Errorf("Expected a bad header to be rejected, got %v", f.Errors)
+	}
+
+	users.MaxErrors = 2
+	f.FillRequest(newUploadRequest("users", "name,email\njohn,john\njane,jane@example.com\nbob,bob\nalice,alice\n"))
+	var errs = users.Errors()
+	if len(errs) != 2 || !strings.Contains(errs[0].Error(), `line 2: "john" is not a valid email`) || !strings.Contains(errs[1].Error(), "line 4:") {
+		t.Errorf("Expected the errors of the first two invalid rows, got %v", errs)
+	}
+	if users.Rows() != nil {
+		t.Errorf("Expected no rows for an invalid file, got %v", users.Rows())
+	}
+
+	users.MaxErrors = 0
+	users.MaxRows = 1
+	if f.FillRequest(newUploadRequest("users", content)) || !strings.Contains(f.Errors.Error(), "more than 1 rows") {
+		t.Errorf("Expected too many rows to be rejected, got %v", f.Errors)
+	}
+	users.MaxRows = 0
+	f.Clear()
+	if f.FillRequest(newUploadRequest("other", content)) || !strings.Contains(f.Errors.Error(), "Users is required") {
+		t.Errorf("Expected a missing upload to be required, got %v", f.Errors)
+	}
+}
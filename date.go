@@ -0,0 +1,170 @@
+package forms
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	TypeDate     = "date"
+	TypeDateTime = "datetime-local"
+	TypeMonth    = "month"
+	TypeWeek     = "week"
+	TypeTime     = "time"
+)
+
+// A DateField is a date, datetime-local, month, week or time input,
+// restricted to the range between MinTime and MaxTime.
+//
+// The bounds are rendered as the min and max attributes, so the browser's picker restricts the selection,
+// and they are checked during Validate at the precision of the field's type.
+type DateField struct {
+	BaseField
+	// The earliest and latest accepted value, the zero time means no bound.
+	MinTime time.Time
+	MaxTime time.Time
+}
+
+// NewDateField creates a field of the given date type, such as TypeDate or TypeWeek.
+// An empty type defaults to TypeDate.
+func NewDateField(name string, typ string) *DateField {
+	if typ == "" {
+		typ = TypeDate
+	}
+	var d = &DateField{}
+	d.Name = name
+	d.Type = typ
+	d.LabelText = DefaultLabeler(name)
+	return d
+}
+
+// DateField adds a field of the given date type, such as TypeDate or TypeWeek.
+func (f *Form) DateField(name string, typ string) *DateField {
+	var field = NewDateField(name, typ)
+	f.AddFields(field)
+	return field
+}
+
+// Field renders the input with the bounds as its min and max attributes.
+func (d *DateField) Field() ElementInterface {
+	if d.MinTime.IsZero() && d.MaxTime.IsZero() {
+		return d.BaseField.Field()
+	}
+	var field = d.BaseField
+	field.Attrs = make(map[string]string, len(d.Attrs)+2)
+	for k, v := range d.Attrs {
+		field.Attrs[k] = v
+	}
+	if !d.MinTime.IsZero() {
+		field.Attrs["min"] = d.Format(d.MinTime)
+	}
+	if !d.MaxTime.IsZero() {
+		field.Attrs["max"] = d.Format(d.MaxTime)
+	}
+	return field.Field()
+}
+
+// Format formats t the way the browser submits values of the field's type, e.g. "2024-W01" for weeks.
+func (d *DateField) Format(t time.Time) string {
+	if d.Type == TypeWeek {
+		var year, week = t.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	}
+	return t.Format(d.layout())
+}
+
+// Parse parses a submitted value of the field's type, weeks are parsed as the Monday they start on.
+func (d *DateField) Parse(value string) (time.Time, error) {
+	if d.Type != TypeWeek {
+		return time.Parse(d.layout(), value)
+	}
+	var year, week, ok = strings.Cut(value, "-W")
+	var y, errYear = strconv.Atoi(year)
+	var w, errWeek = strconv.Atoi(week)
+	if !ok || errYear != nil || errWeek != nil || w < 1 || w > 53 {
+		return time.Time{}, fmt.Errorf("invalid week %q", value)
+	}
+	// January 4th is always in the first ISO week of the year.
+	var jan4 = time.Date(y, time.January, 4, 0, 0, 0, 0, time.UTC)
+	var monday = jan4.AddDate(0, 0, -((int(jan4.Weekday())+6)%7)+(w-1)*7)
+	if _, got := monday.ISOWeek(); got != w {
+		return time.Time{}, fmt.Errorf("invalid week %q", value)
+	}
+	return monday, nil
+}
+
+// Time returns the parsed value of the field, or the zero time when it is empty.
+func (d *DateField) Time() (time.Time, error) {
+	var value = d.Value()
+	if value.String() == "" {
+		return time.Time{}, nil
+	}
+	if t, ok := value.Typed.(time.Time); ok {
+		return t, nil
+	}
+	var t, err = d.Parse(value.String())
+	if err == nil {
+		value.Typed = t
+	}
+	return t, err
+}
+
+func (d *DateField) layout() string {
+	switch d.Type {
+	case TypeDateTime:
+		return "2006-01-02T15:04"
+	case TypeMonth:
+		return "2006-01"
+	case TypeTime:
+		return "15:04"
+	}
+	return "2006-01-02"
+}
+
+// display formats a bound for validation messages, e.g. "1 Jan 2024".
+func (d *DateField) display(t time.Time) string {
+	switch d.Type {
+	case TypeDateTime:
+		return t.Format("2 Jan 2006 15:04")
+	case TypeMonth:
+		return t.Format("Jan 2006")
+	case TypeWeek:
+		var year, week = t.ISOWeek()
+		return fmt.Sprintf("week %d of %d", week, year)
+	case TypeTime:
+		return t.Format("15:04")
+	}
+	return t.Format("2 Jan 2006")
+}
+
+// Validate validates the field like any other field, then checks the value is within the bounds.
+//
+// The errors have the codes "date", "min_date" and "max_date", with the formatted bound as the min or max param.
+func (d *DateField) Validate() error {
+	if err := d.BaseField.Validate(); err != nil {
+		return err
+	}
+	var t, err = d.Time()
+	if err != nil {
+		return d.invalid("date", nil, fmt.Errorf("%s is not a valid %s", d.LabelText, d.Type))
+	}
+	if t.IsZero() {
+		return nil
+	}
+	// Compare at the precision of the type, a bound of 12:30:45 accepts 12:30.
+	if !d.MinTime.IsZero() {
+		if min, _ := d.Parse(d.Format(d.MinTime)); t.Before(min) {
+			var bound = d.display(d.MinTime)
+			return d.invalid("min_date", map[string]any{"min": bound}, fmt.Errorf("%s must be on or after %s", d.LabelText, bound))
+		}
+	}
+	if !d.MaxTime.IsZero() {
+		if max, _ := d.Parse(d.Format(d.MaxTime)); t.After(max) {
+			var bound = d.display(d.MaxTime)
+			return d.invalid("max_date", map[string]any{"max": bound}, fmt.Errorf("%s must be on or before %s", d.LabelText, bound))
+		}
+	}
+	return nil
+}
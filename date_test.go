@@ -0,0 +1,59 @@
+package forms_test
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestDateField(t *testing.T) {
+	var tests = []struct {
+		typ, min, max, inside, before, after, message string
+	}{
+		{forms.TypeDate, "2024-01-01", "2024-12-31", "2024-01-01", "2023-12-31", "2025-01-01", "Start must be on or after 1 Jan 2024"},
+		{forms.TypeDateTime, "2024-01-01T09:00", "2024-12-31T17:30", "2024-12-31T17:30", "2024-01-01T08:59", "2024-12-31T17:31", "Start must be on or after 1 Jan 2024 09:00"},
+		{forms.TypeMonth, "2024-01", "2024-12", "2024-12", "2023-12", "2025-01", "Start must be on or after Jan 2024"},
+		{forms.TypeWeek, "2024-W01", "2025-W01", "2024-W52", "2023-W52", "2025-W02", "Start must be on or after week 1 of 2024"},
+		{forms.TypeTime, "09:00", "17:30", "09:00", "08:59", "17:31", "Start must be on or after 09:00"},
+	}
+	var min = time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	var max = time.Date(2024, time.December, 31, 17, 30, 0, 0, time.UTC)
+	for _, test := range tests {
+		var f = &forms.Form{}
+		var d = f.DateField("start", test.typ)
+		d.MinTime, d.MaxTime = min, max
+		var html = d.Field().String()
+		if !strings.Contains(html, `type="`+test.typ+`"`) || !strings.Contains(html, `min="`+test.min+`"`) || !strings.Contains(html, `max="`+test.max+`"`) {
+			t.Errorf("Expected the %s bounds %s and %s to be rendered, got %s", test.typ, test.min, test.max, html)
+		}
+
+		for value, expected := range map[string]string{test.inside: "", test.before: test.message, test.after: "must be on or before"} {
+			var valid = f.FillValues(url.Values{"start": {value}})
+			if expected == "" && !valid {
+				t.Errorf("Expected %s %s to be valid, got %v", test.typ, value, f.Errors)
+			}
+			if expected != "" && (valid || !strings.Contains(f.Errors.Error(), expected)) {
+				t.Errorf("Expected %s %s to fail with %q, got %v", test.typ, value, expected, f.Errors)
+			}
+		}
+	}
+
+	var f = &forms.Form{}
+	f.DateField("start", forms.TypeDate).MinTime = min
+	f.Translator = func(code string, params map[string]any) string {
+		if code == "min_date" {
+			return fmt.Sprintf("%s moet op of na %s liggen", params["label"], params["min"])
+		}
+		return ""
+	}
+	if f.FillValues(url.Values{"start": {"2023-06-01"}}) || f.Errors[0].FieldErr.Error() != "Start moet op of na 1 Jan 2024 liggen" {
+		t.Errorf("Expected the bound to be passed to the translator, got %v", f.Errors)
+	}
+	if f.FillValues(url.Values{"start": {"June 1st"}}) || f.Errors.AsMap(forms.ErrorCodes)["start"][0] != "date" {
+		t.Errorf("Expected an invalid date to fail, got %v", f.Errors)
+	}
+}
@@ -0,0 +1,81 @@
+package forms
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Nigel2392/forms/validators"
+)
+
+// DecimalField adds a field for decimal numbers, such as prices.
+//
+// The value is kept as a string to avoid floating point rounding,
+// and validated to have at most maxDigits digits, of which at most decimalPlaces after the decimal point.
+// A decimalPlaces of 0 only allows whole numbers, a negative decimalPlaces means no limit, see validators.Decimal.
+//
+// The field renders as a text input with inputmode="decimal" and a matching pattern attribute.
+func (f *Form) DecimalField(name string, id string, classes string, placeholder string, value string, maxDigits int, decimalPlaces int) *Field {
+	var field = newField(TypeText, name, id, classes, placeholder, value)
+	field.InputMode = "decimal"
+	field.MaxDigits = maxDigits
+	field.DecimalPlaces = decimalPlaces
+	field.Pattern = decimalPattern("", decimalPlaces)
+	field.Validators = validators.New(
+		validators.Decimal(maxDigits, decimalPlaces),
+	)
+	f.AddFields(field)
+	return field
+}
+
+// SetCurrencySymbol sets the currency symbol which may prefix submitted values,
+// and is stripped before validation.
+func (f *Field) SetCurrencySymbol(symbol string) {
+	f.CurrencySymbol = symbol
+	f.Pattern = decimalPattern(symbol, f.DecimalPlaces)
+}
+
+func decimalPattern(symbol string, decimalPlaces int) string {
+	var b strings.Builder
+	if symbol != "" {
+		b.WriteString("(" + regexp.QuoteMeta(symbol) + ")?\\s*")
+	}
+	b.WriteString(`[+\-]?[0-9]+`)
+	switch {
+	case decimalPlaces < 0:
+		b.WriteString(`([.][0-9]+)?`)
+	case decimalPlaces > 0:
+		b.WriteString(`([.][0-9]{1,` + strconv.Itoa(decimalPlaces) + `})?`)
+	}
+	return b.String()
+}
+
+// toMinorUnits converts a decimal string into an integer amount of minor units, e.g. "12.5" with 2 places is 1250.
+//
+// A negative decimalPlaces is treated as 0, as there are no minor units without a fixed number of places.
+func toMinorUnits(s string, decimalPlaces int) (int64, error) {
+	if decimalPlaces < 0 {
+		decimalPlaces = 0
+	}
+	var negative = strings.HasPrefix(s, "-")
+	if negative || strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+	var intPart, fracPart, _ = strings.Cut(s, ".")
+	if len(fracPart) > decimalPlaces {
+		return 0, fmt.Errorf("%s has more than %d decimal places", s, decimalPlaces)
+	}
+	fracPart += strings.Repeat("0", decimalPlaces-len(fracPart))
+	if intPart == "" {
+		intPart = "0"
+	}
+	var i, err = strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid decimal")
+	}
+	if negative {
+		i = -i
+	}
+	return i, nil
+}
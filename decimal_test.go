@@ -0,0 +1,72 @@
+package forms_test
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+type Decimal struct {
+	Raw string
+}
+
+func (d *Decimal) ScanStr(s string) error {
+	d.Raw = s
+	return nil
+}
+
+func TestDecimalField(t *testing.T) {
+	var f = forms.Form{}
+	var price = f.DecimalField("price", "price", "", "", "", 6, 2)
+	price.SetCurrencySymbol("€")
+	price.MinorUnits = true
+
+	var rendered = price.Field().String()
+	if !strings.Contains(rendered, `type="text"`) || !strings.Contains(rendered, `inputmode="decimal"`) || !strings.Contains(rendered, `pattern="`) {
+		t.Errorf("Expected a decimal text input with a pattern, got %s", rendered)
+	}
+
+	if !f.Fill(newPostRequest(url.Values{"price": {"€ 1234.5"}})) {
+		t.Fatalf("Expected the price to be valid, got %s", f.Errors)
+	}
+	var cents int64
+	var dec Decimal
+	if err := f.Scan([]string{"price"}, &cents); err != nil || cents != 123450 {
+		t.Errorf("Expected 123450 cents, got %d (%v)", cents, err)
+	}
+	if err := f.Scan([]string{"price"}, &dec); err != nil || dec.Raw != "1234.5" {
+		t.Errorf("Expected the decimal to be scanned as 1234.5, got %q (%v)", dec.Raw, err)
+	}
+
+	for _, invalid := range []string{"12.345", "12345678", "12a", "--5", "+-5", "-+5"} {
+		if f.Fill(newPostRequest(url.Values{"price": {invalid}})) {
+			t.Errorf("Expected %q to be an invalid price", invalid)
+		}
+	}
+	if !f.Fill(newPostRequest(url.Values{"price": {"-5"}})) {
+		t.Fatalf("Expected a negative price to be valid, got %s", f.Errors)
+	}
+	if err := f.Scan([]string{"price"}, &cents); err != nil || cents != -500 {
+		t.Errorf("Expected -500 cents, got %d (%v)", cents, err)
+	}
+
+	for places, tests := range map[int]map[string]bool{
+		0:  {"12": true, "12.5": false, "+12": true},
+		-1: {"12": true, "12.12345": true, "-12.5": true},
+	} {
+		var f = forms.Form{}
+		var amount = f.DecimalField("amount", "amount", "", "", "", 0, places)
+		var pattern = regexp.MustCompile("^(?:" + amount.Pattern + ")$")
+		for value, valid := range tests {
+			if got := f.Fill(newPostRequest(url.Values{"amount": {value}})); got != valid {
+				t.Errorf("Expected %q to be valid=%t with %d places, got %t", value, valid, places, got)
+			}
+			if got := pattern.MatchString(value); got != valid {
+				t.Errorf("Expected the pattern %s to match %q=%t, got %t", amount.Pattern, value, valid, got)
+			}
+		}
+	}
+}
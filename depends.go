@@ -0,0 +1,123 @@
+package forms
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dependency makes a field conditional on the value of another field.
+//
+// The field is only validated when the field named by Field holds one of Values.
+// If Values is empty, any non-empty value of the other field satisfies the dependency.
+//
+// If Condition is set, it takes precedence over Field and Values.
+type Dependency struct {
+	Field     string
+	Values    []string
+	Condition func(*Form) bool
+}
+
+type dependent interface {
+	dependency() *Dependency
+}
+
+func (f *Field) dependency() *Dependency {
+	return f.DependsOn
+}
+
+// Check if the dependency is met by the current value of the other field.
+func (d *Dependency) met(form *Form) bool {
+	if d.Condition != nil {
+		return d.Condition(form)
+	}
+	var other = form.Field(d.Field)
+	if other == nil {
+		return false
+	}
+	for _, v := range other.GetValue() {
+		if v == "" {
+			continue
+		}
+		if len(d.Values) == 0 {
+			return true
+		}
+		for _, want := range d.Values {
+			if v == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inactiveFields resolves all field dependencies, and returns the names of the fields which should be skipped.
+//
+// A field is inactive when its own dependency is not met, or when the field it depends on is inactive.
+//
+// Circular dependencies are reported once, fields in the cycle are treated as active.
+func (f *Form) inactiveFields() (map[string]bool, error) {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	var (
+		state    = make(map[string]int)
+		active   = make(map[string]bool)
+		inactive = make(map[string]bool)
+		path     = make([]string, 0)
+		cycleErr error
+	)
+
+	var resolve func(name string) bool
+	resolve = func(name string) bool {
+		switch state[name] {
+		case done:
+			return active[name]
+		case visiting:
+			if cycleErr == nil {
+				var start = 0
+				for i, p := range path {
+					if p == name {
+						start = i
+						break
+					}
+				}
+				var cycle = append(append([]string{}, path[start:]...), name)
+				cycleErr = fmt.Errorf("circular field dependency: %s", strings.Join(cycle, " -> "))
+			}
+			return true
+		}
+
+		var field = f.Field(name)
+		var dep *Dependency
+		if d, ok := field.(dependent); ok {
+			dep = d.dependency()
+		}
+		if dep == nil {
+			state[name] = done
+			active[name] = true
+			return true
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		var isActive = true
+		if dep.Condition == nil && dep.Field != "" {
+			isActive = resolve(dep.Field)
+		}
+		isActive = isActive && dep.met(f)
+		path = path[:len(path)-1]
+
+		state[name] = done
+		active[name] = isActive
+		return isActive
+	}
+
+	for _, field := range f.Fields {
+		if !resolve(field.GetName()) {
+			inactive[field.GetName()] = true
+		}
+	}
+	return inactive, cycleErr
+}
@@ -0,0 +1,38 @@
+package forms_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestDependsOn(t *testing.T) {
+	var f = &forms.Form{}
+	var accountType = f.TextField("account_type", "account_type", "", "", "personal")
+	var company = f.TextField("company", "company", "", "", "")
+	company.Required = true
+	company.DependsOn = &forms.Dependency{Field: "account_type", Values: []string{"business"}}
+	if !f.Validate() {
+		t.Errorf("Expected company to be skipped for personal accounts")
+	}
+	accountType.SetValue([]string{"business"})
+	if f.Validate() {
+		t.Errorf("Expected company to be required for business accounts")
+	}
+	if !strings.Contains(f.Field("company").Field().String(), `data-depends-on="account_type" data-depends-values="business"`) {
+		t.Errorf("Expected dependency data attributes, got %s", f.Field("company").Field().String())
+	}
+
+	var cyclic = &forms.Form{}
+	var a = cyclic.TextField("a", "a", "", "", "x")
+	var b = cyclic.TextField("b", "b", "", "", "x")
+	a.DependsOn = &forms.Dependency{Field: "b"}
+	b.DependsOn = &forms.Dependency{Field: "a"}
+	if cyclic.Validate() {
+		t.Errorf("Expected circular dependency to invalidate the form")
+	}
+	if len(cyclic.Errors) != 1 {
+		t.Errorf("Expected circular dependency to be reported once, got %d errors", len(cyclic.Errors))
+	}
+}
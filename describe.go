@@ -0,0 +1,149 @@
+package forms
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Nigel2392/forms/validators"
+)
+
+// A FormReport describes the fields of a form, see Form.Describe.
+//
+// It renders as a plain-text table with String, and encodes to JSON.
+type FormReport struct {
+	Name   string        `json:"name,omitempty"`
+	Fields []FieldReport `json:"fields"`
+}
+
+// A FieldReport describes a field of a form.
+type FieldReport struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	ID       string `json:"id,omitempty"`
+	Required bool   `json:"required"`
+	// The bounds of the field, nil when the field has none, see Field.HasMin and Field.HasMax.
+	Min *int `json:"min,omitempty"`
+	Max *int `json:"max,omitempty"`
+	// The rules of the validators which can describe themselves, see validators.Describe.
+	Validators []validators.Rule `json:"validators,omitempty"`
+	// The number of validators which cannot describe themselves, such as plain functions.
+	OpaqueValidators int `json:"opaque_validators,omitempty"`
+	Options          int `json:"options,omitempty"`
+	// Whether the field currently holds a value or a file.
+	Bound bool `json:"bound"`
+}
+
+// Describe reports the fields of the form: their name, type, effective ID, bounds, validators,
+// number of options and whether they hold a value, e.g. to debug forms generated from structs.
+//
+// Fields which do not embed a Field are reported by their name, Go type and value only.
+func (f *Form) Describe() FormReport {
+	if f == nil {
+		return FormReport{Fields: []FieldReport{}}
+	}
+	var report = FormReport{Name: f.Name, Fields: make([]FieldReport, 0, len(f.Fields))}
+	for _, el := range f.Fields {
+		report.Fields = append(report.Fields, describeField(el))
+	}
+	return report
+}
+
+func describeField(el FormElement) FieldReport {
+	var r = FieldReport{
+		Name:    el.GetName(),
+		Options: len(el.GetOptions()),
+		Bound:   el.IsFile(),
+	}
+	for _, v := range el.GetValue() {
+		r.Bound = r.Bound || v != ""
+	}
+	var fld, ok = asField(el)
+	if !ok {
+		r.Type = fmt.Sprintf("%T", el)
+		return r
+	}
+	r.Type = fld.Type
+	r.ID = fld.EffectiveID()
+	r.Required = fld.Required
+	if fld.HasMin() {
+		var min = fld.Min
+		r.Min = &min
+	}
+	if fld.HasMax() {
+		var max = fld.Max
+		r.Max = &max
+	}
+	for _, v := range fld.Validators {
+		if rule, ok := validators.Describe(v); ok {
+			r.Validators = append(r.Validators, rule)
+		} else {
+			r.OpaqueValidators++
+		}
+	}
+	return r
+}
+
+// String renders the report as a plain-text table, one row per field.
+func (r FormReport) String() string {
+	var b strings.Builder
+	if r.Name != "" {
+		b.WriteString(r.Name)
+		b.WriteString("\n")
+	}
+	var w = tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tTYPE\tID\tREQUIRED\tMIN\tMAX\tOPTIONS\tBOUND\tVALIDATORS")
+	for _, field := range r.Fields {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\t%s\t%d\t%t\t%s\n",
+			field.Name, field.Type, orDash(field.ID), field.Required,
+			formatBound(field.Min), formatBound(field.Max), field.Options, field.Bound, field.validators(),
+		)
+	}
+	w.Flush()
+	return b.String()
+}
+
+// validators lists the rules of the validators, e.g. "max_length(max=10), 1 opaque".
+func (r FieldReport) validators() string {
+	var parts = make([]string, 0, len(r.Validators)+1)
+	for _, rule := range r.Validators {
+		parts = append(parts, ruleString(rule))
+	}
+	if r.OpaqueValidators > 0 {
+		parts = append(parts, strconv.Itoa(r.OpaqueValidators)+" opaque")
+	}
+	return orDash(strings.Join(parts, ", "))
+}
+
+// ruleString formats a rule with its params sorted by name, e.g. "length(max=10, min=2)".
+func ruleString(rule validators.Rule) string {
+	if len(rule.Params) == 0 {
+		return rule.Code
+	}
+	var keys = make([]string, 0, len(rule.Params))
+	for k := range rule.Params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var params = make([]string, len(keys))
+	for i, k := range keys {
+		params[i] = fmt.Sprintf("%s=%v", k, rule.Params[k])
+	}
+	return rule.Code + "(" + strings.Join(params, ", ") + ")"
+}
+
+func formatBound(v *int) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.Itoa(*v)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
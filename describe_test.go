@@ -0,0 +1,42 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+	"github.com/Nigel2392/forms/validators"
+)
+
+func TestFormDescribe(t *testing.T) {
+	var f = &forms.Form{Name: "signup", Prefix: "signup"}
+	var name = f.TextField("name", "", "", "", "John")
+	name.Required = true
+	name.Max = 20
+	name.Validators = validators.New(validators.Length(2, 20), func(validators.FormValue) error { return nil })
+	f.NumberField("age", "age", "", "", 0).Min = 18
+	f.NumberSelectField("category", "category", "", []forms.Option{forms.OptInt(1, "News"), forms.OptInt(2, "Sports")})
+
+	var report = f.Describe()
+	var want = "signup\n" +
+		"NAME      TYPE    ID               REQUIRED  MIN  MAX  OPTIONS  BOUND  VALIDATORS\n" +
+		"name      text    signup-name      true      -    20   0        true   length(max=20, min=2), 1 opaque\n" +
+		"age       number  signup-age       false     18   -    0        true   -\n" +
+		"category  select  signup-category  false     -    -    2        false  one_of(choices=[1 2])\n"
+	if got := report.String(); got != want {
+		t.Errorf("Unexpected report:\n%s\nexpected:\n%s", got, want)
+	}
+
+	var data, err = json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded forms.FormReport
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Fields) != 3 || decoded.Fields[0].ID != "signup-name" || *decoded.Fields[0].Max != 20 || decoded.Fields[0].Min != nil ||
+		decoded.Fields[0].OpaqueValidators != 1 || decoded.Fields[2].Options != 2 || decoded.Fields[2].Bound {
+		t.Errorf("Unexpected JSON report %s", data)
+	}
+}
@@ -0,0 +1,183 @@
+package forms
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A DurationFormat is a format accepted by a DurationField.
+type DurationFormat string
+
+const (
+	// Go durations, parsed with time.ParseDuration, e.g. "1h30m" or "90m".
+	DurationGo DurationFormat = "go"
+	// Clock notation with hours and minutes, and optionally seconds, e.g. "01:30" or "1:30:15".
+	DurationClock DurationFormat = "clock"
+)
+
+// A DurationField accepts durations in the Formats, restricted to the range between MinDuration and MaxDuration.
+//
+// The value is rendered back in the first of its Formats, and scanned into time.Duration destinations by Scan.
+type DurationField struct {
+	BaseField
+	// The accepted formats, defaults to DurationGo and DurationClock.
+	Formats []DurationFormat
+	// The shortest and longest accepted duration, 0 means no bound.
+	MinDuration time.Duration
+	MaxDuration time.Duration
+}
+
+// NewDurationField creates a field for durations in the given formats, all formats are accepted when none are given.
+func NewDurationField(name string, formats ...DurationFormat) *DurationField {
+	var d = &DurationField{Formats: formats}
+	d.Name = name
+	d.Type = TypeText
+	d.LabelText = DefaultLabeler(name)
+	d.DisplayFormatter = d.canonical
+	return d
+}
+
+// DurationField adds a field for durations in the given formats, all formats are accepted when none are given.
+func (f *Form) DurationField(name string, formats ...DurationFormat) *DurationField {
+	var field = NewDurationField(name, formats...)
+	f.AddFields(field)
+	return field
+}
+
+func (d *DurationField) formats() []DurationFormat {
+	if len(d.Formats) == 0 {
+		return []DurationFormat{DurationGo, DurationClock}
+	}
+	return d.Formats
+}
+
+// Parse parses a duration in any of the accepted formats.
+func (d *DurationField) Parse(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	for _, format := range d.formats() {
+		var v, err = parseDuration(format, value)
+		if err == nil {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid duration %q", value)
+}
+
+// Format formats the duration in the first of the accepted formats.
+func (d *DurationField) Format(v time.Duration) string {
+	return formatDuration(d.formats()[0], v)
+}
+
+// Duration returns the parsed value of the field, or 0 when it is empty.
+func (d *DurationField) Duration() (time.Duration, error) {
+	var value = d.Value()
+	if value.String() == "" {
+		return 0, nil
+	}
+	if v, ok := value.Typed.(time.Duration); ok {
+		return v, nil
+	}
+	var parsed, err = d.Parse(value.String())
+	if err == nil {
+		value.Typed = parsed
+	}
+	return parsed, err
+}
+
+// canonical formats a valid submitted value in the first accepted format, invalid values are kept as submitted.
+func (d *DurationField) canonical(value string) string {
+	var v, err = d.Parse(value)
+	if err != nil {
+		return value
+	}
+	return d.Format(v)
+}
+
+// Validate validates the field like any other field, then checks the value is a duration within the bounds.
+//
+// The errors have the codes "duration", "min_duration" and "max_duration", with the formatted bound as the min or max param.
+func (d *DurationField) Validate() error {
+	if err := d.BaseField.Validate(); err != nil {
+		return err
+	}
+	var v, err = d.Duration()
+	if err != nil {
+		return d.invalid("duration", nil, fmt.Errorf("%s is not a valid duration", d.LabelText))
+	}
+	if d.Value().String() == "" {
+		return nil
+	}
+	if d.MinDuration != 0 && v < d.MinDuration {
+		var bound = d.Format(d.MinDuration)
+		return d.invalid("min_duration", map[string]any{"min": bound}, fmt.Errorf("%s must be at least %s", d.LabelText, bound))
+	}
+	if d.MaxDuration != 0 && v > d.MaxDuration {
+		var bound = d.Format(d.MaxDuration)
+		return d.invalid("max_duration", map[string]any{"max": bound}, fmt.Errorf("%s must be at most %s", d.LabelText, bound))
+	}
+	return nil
+}
+
+func parseDuration(format DurationFormat, value string) (time.Duration, error) {
+	switch format {
+	case DurationGo:
+		return time.ParseDuration(value)
+	case DurationClock:
+		var parts = strings.Split(value, ":")
+		if len(parts) < 2 || len(parts) > 3 {
+			return 0, errors.New("expected hh:mm or hh:mm:ss")
+		}
+		var v time.Duration
+		for i, part := range parts {
+			var n, err = strconv.Atoi(part)
+			if err != nil || n < 0 || i > 0 && (n > 59 || len(part) != 2) {
+				return 0, errors.New("expected hh:mm or hh:mm:ss")
+			}
+			v += time.Duration(n) * []time.Duration{time.Hour, time.Minute, time.Second}[i]
+		}
+		return v, nil
+	}
+	return 0, fmt.Errorf("unknown duration format %q", format)
+}
+
+func formatDuration(format DurationFormat, v time.Duration) string {
+	if format == DurationClock {
+		var h, m, s = int(v / time.Hour), int(v % time.Hour / time.Minute), int(v % time.Minute / time.Second)
+		if s != 0 {
+			return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+		}
+		return fmt.Sprintf("%02d:%02d", h, m)
+	}
+	// time.Duration.String writes 1h30m0s, drop the trailing zero units.
+	var str = v.String()
+	if strings.HasSuffix(str, "m0s") {
+		str = strings.TrimSuffix(str, "0s")
+	}
+	if strings.HasSuffix(str, "h0m") {
+		str = strings.TrimSuffix(str, "0m")
+	}
+	return str
+}
+
+type durationer interface {
+	Duration() (time.Duration, error)
+}
+
+// scanDuration parses the value of a field scanned into a time.Duration,
+// with the formats of a DurationField, as a Go duration, or as a number of nanoseconds.
+func scanDuration(field FormElement, value string) (time.Duration, error) {
+	if d, ok := field.(durationer); ok {
+		return d.Duration()
+	}
+	if v, err := time.ParseDuration(value); err == nil {
+		return v, nil
+	}
+	var n, err = strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid duration")
+	}
+	return time.Duration(n), nil
+}
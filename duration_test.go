@@ -0,0 +1,57 @@
+package forms_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestDurationField(t *testing.T) {
+	var tests = []struct {
+		value    string
+		expected time.Duration
+		rendered string
+	}{
+		{"1h30m", 90 * time.Minute, "1h30m"},
+		{"90m", 90 * time.Minute, "1h30m"},
+		{"01:30", 90 * time.Minute, "1h30m"},
+		{"0:45:30", 45*time.Minute + 30*time.Second, "45m30s"},
+	}
+	var f = &forms.Form{}
+	var d = f.DurationField("break")
+	d.MinDuration = 15 * time.Minute
+	d.MaxDuration = 2 * time.Hour
+	for _, test := range tests {
+		if !f.FillValues(url.Values{"break": {test.value}}) {
+			t.Errorf("Expected %s to be valid, got %v", test.value, f.Errors)
+			continue
+		}
+		var got time.Duration
+		if err := f.Scan([]string{"break"}, &got); err != nil || got != test.expected {
+			t.Errorf("Expected %s to scan into %s, got %s (%v)", test.value, test.expected, got, err)
+		}
+		if html := d.Field().String(); !strings.Contains(html, `value="`+test.rendered+`"`) {
+			t.Errorf("Expected %s to be rendered as %s, got %s", test.value, test.rendered, html)
+		}
+	}
+
+	for value, code := range map[string]string{"1.5 hours": "duration", "10m": "min_duration", "2h1m": "max_duration", "1:5": "duration"} {
+		if f.FillValues(url.Values{"break": {value}}) || f.Errors.AsMap(forms.ErrorCodes)["break"][0] != code {
+			t.Errorf("Expected %s to fail with %s, got %v", value, code, f.Errors)
+		}
+	}
+
+	f = &forms.Form{}
+	d = f.DurationField("break", forms.DurationClock)
+	if f.FillValues(url.Values{"break": {"90m"}}) {
+		t.Errorf("Expected a Go duration to be rejected by a clock-only field")
+	}
+	f = &forms.Form{}
+	d = f.DurationField("break", forms.DurationClock, forms.DurationGo)
+	if !f.FillValues(url.Values{"break": {"90m"}}) || !strings.Contains(d.Field().String(), `value="01:30"`) {
+		t.Errorf("Expected the value to be rendered in the clock format, got %s", d.Field().String())
+	}
+}
@@ -0,0 +1,169 @@
+package forms
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/Nigel2392/forms/validators"
+)
+
+// Fields which hold the state of a submission rather than configuration, ignored by Field.Equal.
+var stateFields = map[string]bool{
+	"FormValue":    true,
+	"Checked":      true,
+	"RawSubmitted": true,
+	"FormErrors":   true,
+}
+
+// Equal reports whether the fields are configured the same: name, type, label, constraints, options, attributes and so on.
+// Values and errors are not compared, see Form.Diff.
+//
+// Functions, such as the DisplayFormatter, cannot be compared, only whether they are set.
+// Validators are compared by their rules, see validators.Describe.
+func (f *Field) Equal(other *Field) bool {
+	return len(f.diff(other)) == 0
+}
+
+// diff lists the configuration which differs between the fields, e.g. `Type: "text" != "email"`.
+func (f *Field) diff(other *Field) []string {
+	if f == nil || other == nil {
+		if f == other {
+			return nil
+		}
+		return []string{fmt.Sprintf("%v != %v", f, other)}
+	}
+	var diffs []string
+	var a, b = reflect.ValueOf(f).Elem(), reflect.ValueOf(other).Elem()
+	var typ = a.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		var sf = typ.Field(i)
+		if !sf.IsExported() || stateFields[sf.Name] {
+			continue
+		}
+		var x, y = configValue(a.Field(i)), configValue(b.Field(i))
+		if !reflect.DeepEqual(x, y) {
+			diffs = append(diffs, fmt.Sprintf("%s: %v != %v", sf.Name, x, y))
+		}
+	}
+	return diffs
+}
+
+// configValue returns a comparable representation of a configuration value of a field.
+func configValue(v reflect.Value) any {
+	switch value := v.Interface().(type) {
+	case []Option:
+		var options = make([]string, len(value))
+		for i, o := range value {
+			var val []string
+			if o.Value != nil {
+				val = o.Value.Val
+			}
+			options[i] = fmt.Sprintf("%q=%q selected=%t", o.Text, val, o.Selected)
+		}
+		return options
+	case []validators.Validator:
+		var rules = make([]string, len(value))
+		for i, validator := range value {
+			if rule, ok := validators.Describe(validator); ok {
+				rules[i] = fmt.Sprintf("%s %v", rule.Code, rule.Params)
+			} else {
+				rules[i] = "func"
+			}
+		}
+		return rules
+	case *Dependency:
+		if value == nil {
+			return nil
+		}
+		return fmt.Sprintf("%s in %q, condition %t", value.Field, value.Values, value.Condition != nil)
+	case Widget:
+		return fmt.Sprintf("%T", value)
+	}
+	switch v.Kind() {
+	case reflect.Func:
+		if v.IsNil() {
+			return "nil"
+		}
+		return "func"
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Func {
+			return fmt.Sprintf("%d funcs", v.Len())
+		}
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return v.Elem().Interface()
+	case reflect.Map:
+		if v.Len() == 0 {
+			return nil
+		}
+	}
+	return v.Interface()
+}
+
+// Equal reports whether the forms have the same fields configured the same way, in any order, see Diff.
+func (f *Form) Equal(other *Form) bool {
+	return len(f.Diff(other)) == 0
+}
+
+// Diff lists the differences between the fields of the forms, matched by name regardless of their order,
+// e.g. to assert refactored construction code produces the same form. See Field.Equal.
+//
+// Fields which do not embed a Field are only compared by name.
+func (f *Form) Diff(other *Form) []string {
+	var diffs []string
+	var names = make(map[string]bool)
+	var mine, theirs = f.fieldsByName(), other.fieldsByName()
+	for name := range mine {
+		names[name] = true
+	}
+	for name := range theirs {
+		names[name] = true
+	}
+	var sorted = make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	for _, name := range sorted {
+		var a, inMine = mine[name]
+		var b, inTheirs = theirs[name]
+		switch {
+		case !inTheirs:
+			diffs = append(diffs, fmt.Sprintf("%s: missing from the other form", name))
+			continue
+		case !inMine:
+			diffs = append(diffs, fmt.Sprintf("%s: missing from this form", name))
+			continue
+		}
+		var x, okA = asField(a)
+		var y, okB = asField(b)
+		if !okA || !okB {
+			if reflect.TypeOf(a) != reflect.TypeOf(b) {
+				diffs = append(diffs, fmt.Sprintf("%s: %T != %T", name, a, b))
+			}
+			continue
+		}
+		for _, d := range x.diff(y) {
+			diffs = append(diffs, name+": "+d)
+		}
+	}
+	return diffs
+}
+
+// fieldsByName returns the fields keyed by name, the first field wins if a name is used twice.
+func (f *Form) fieldsByName() map[string]FormElement {
+	var fields = make(map[string]FormElement)
+	if f == nil {
+		return fields
+	}
+	for _, field := range f.Fields {
+		var key = f.nameKey(field.GetName())
+		if _, ok := fields[key]; !ok {
+			fields[key] = field
+		}
+	}
+	return fields
+}
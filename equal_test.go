@@ -0,0 +1,48 @@
+package forms_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+	"github.com/Nigel2392/forms/validators"
+)
+
+func TestFormEqual(t *testing.T) {
+	var a = &forms.Form{}
+	a.AddTextField("username", forms.WithRequired(), forms.WithValidators(validators.MaxLength(150)))
+	a.AddSelectField("color", forms.WithOptions([]forms.Option{{Text: "Red", Value: forms.NewValue("red")}}))
+	var b, c = &forms.Form{}, &forms.Form{}
+	for _, field := range a.Fields {
+		b.AddFields(field.(*forms.Field).Clone())
+		c.AddFields(field.(*forms.Field).Clone())
+	}
+
+	b.Fields[0], b.Fields[1] = b.Fields[1], b.Fields[0]
+	b.FillValues(url.Values{"username": {"john"}})
+	if !a.Equal(b) {
+		t.Errorf("Expected forms differing in field order and values to be equal, got %v", a.Diff(b))
+	}
+
+	var username = c.Field("username").(*forms.Field)
+	username.Validators = validators.New(validators.MaxLength(100))
+	var diff = a.Diff(c)
+	if a.Equal(c) || len(diff) != 1 || !strings.HasPrefix(diff[0], "username: Validators:") {
+		t.Errorf("Expected a single difference in the validators of username, got %v", diff)
+	}
+
+	username.Validators = validators.New(validators.MaxLength(150))
+	c.Field("color").(*forms.Field).Options[0].Text = "Rood"
+	c.AddHiddenField("next")
+	diff = a.Diff(c)
+	if len(diff) != 2 || !strings.HasPrefix(diff[0], "color: Options:") || diff[1] != "next: missing from this form" {
+		t.Errorf("Expected the options and the extra field to differ, got %v", diff)
+	}
+
+	var field = forms.New("email", forms.WithType(forms.TypeEmail))
+	var other = forms.New("email", forms.WithType(forms.TypeEmail), forms.WithAutocomplete(forms.AutocompleteOff))
+	if field.Equal(other) || !field.Equal(field.Clone()) {
+		t.Errorf("Expected the autocomplete attribute to be compared")
+	}
+}
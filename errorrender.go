@@ -0,0 +1,118 @@
+package forms
+
+import (
+	"html/template"
+	"strings"
+)
+
+// An ErrorRenderer renders the errors of a field, e.g. ListErrors or InlineErrors.
+//
+// It is looked up on the field first, then on its form, and defaults to ListErrors.
+type ErrorRenderer func(field FormElement, errs []FormError) template.HTML
+
+// ListErrors renders the errors as a <ul class="errorlist">, one <li> per error.
+func ListErrors(field FormElement, errs []FormError) template.HTML {
+	var newline = errorNewline(field)
+	var b strings.Builder
+	b.WriteString(`<ul class="errorlist">`)
+	b.WriteString(newline)
+	for _, err := range errs {
+		b.WriteString(`<li>`)
+		b.WriteString(template.HTMLEscapeString(err.FieldErr.Error()))
+		b.WriteString(`</li>`)
+		b.WriteString(newline)
+	}
+	b.WriteString(`</ul>`)
+	b.WriteString(newline)
+	return template.HTML(b.String())
+}
+
+// InlineErrors renders the errors as a single <span class="error">, separated by a space.
+func InlineErrors(field FormElement, errs []FormError) template.HTML {
+	var b strings.Builder
+	b.WriteString(`<span class="error">`)
+	for i, err := range errs {
+		if i > 0 {
+			b.WriteString(` `)
+		}
+		b.WriteString(template.HTMLEscapeString(err.FieldErr.Error()))
+	}
+	b.WriteString(`</span>`)
+	b.WriteString(errorNewline(field))
+	return template.HTML(b.String())
+}
+
+func errorNewline(field FormElement) string {
+	if fld, ok := asField(field); ok {
+		return fld.newline()
+	}
+	return DefaultRenderConfig.newline()
+}
+
+// renderErrors renders the errors of the field with its ErrorRenderer, nothing is rendered without errors.
+func renderErrors(field FormElement) template.HTML {
+	var errs = field.Errors()
+	if len(errs) == 0 {
+		return ""
+	}
+	var render ErrorRenderer = ListErrors
+	if fld, ok := asField(field); ok {
+		if fld.ErrorRenderer != nil {
+			render = fld.ErrorRenderer
+		} else if fld.form != nil && fld.form.ErrorRenderer != nil {
+			render = fld.form.ErrorRenderer
+		}
+	}
+	return render(field, errs)
+}
+
+// fieldErrors renders the errors of the field like renderErrors,
+// inside of the container targeted by fields validated on blur.
+func fieldErrors(field FormElement) template.HTML {
+	var fld, ok = asField(field)
+	if !ok || !fld.validateOnBlur {
+		return renderErrors(field)
+	}
+	return template.HTML(`<div id="` + template.HTMLEscapeString(fld.errorsID()) + `">` + string(renderErrors(field)) + `</div>`)
+}
+
+// writeHiddenErrors writes the errors of the hidden fields as a single <ul class="errorlist nonfield">,
+// prefixing each error with the name of its field.
+func writeHiddenErrors(b *strings.Builder, fields []FormElement) {
+	var started bool
+	var newline string
+	for _, field := range fields {
+		for _, err := range field.Errors() {
+			if !started {
+				started = true
+				newline = errorNewline(field)
+				b.WriteString(`<ul class="errorlist nonfield">`)
+				b.WriteString(newline)
+			}
+			b.WriteString(`<li>(Hidden field `)
+			b.WriteString(template.HTMLEscapeString(field.GetName()))
+			b.WriteString(`) `)
+			b.WriteString(template.HTMLEscapeString(err.FieldErr.Error()))
+			b.WriteString(`</li>`)
+			b.WriteString(newline)
+		}
+	}
+	if started {
+		b.WriteString(`</ul>`)
+		b.WriteString(newline)
+	}
+}
+
+// RenderErrors renders the errors of the named field with its ErrorRenderer,
+// nothing is rendered when the field has no errors or does not exist.
+func (f *Form) RenderErrors(name string) template.HTML {
+	if f == nil {
+		return ""
+	}
+	f.bind()
+	var field = f.Field(name)
+	if field == nil {
+		return ""
+	}
+	return renderErrors(field)
+}
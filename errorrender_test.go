@@ -0,0 +1,42 @@
+package forms_test
+
+import (
+	"errors"
+	"html/template"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestErrorRenderer(t *testing.T) {
+	var f = &forms.Form{RenderConfig: &forms.RenderConfig{Compact: true}}
+	f.AddTextField("name", forms.WithRequired())
+	f.AddEmailField("email", forms.WithRequired())
+	f.FillValues(url.Values{"name": {"<b>"}, "email": {""}})
+	f.Field("name").AddError(errors.New("<b> is not allowed"))
+	if html := string(f.AsP()); !strings.Contains(html, `<ul class="errorlist"><li>&lt;b&gt; is not allowed</li></ul><p>`) ||
+		!strings.Contains(html, `<ul class="errorlist"><li>Email is required</li></ul>`) {
+		t.Errorf("Expected the errors to be rendered as lists by default, got %s", html)
+	}
+
+	f.ErrorRenderer = forms.InlineErrors
+	var html = string(f.AsP())
+	if strings.Contains(html, "errorlist") || !strings.Contains(html, `<span class="error">Email is required</span>`) {
+		t.Errorf("Expected the form's renderer to be used for all fields, got %s", html)
+	}
+	if got := string(f.RenderErrors("email")); got != `<span class="error">Email is required</span>` {
+		t.Errorf("Expected RenderErrors to use the form's renderer, got %s", got)
+	}
+
+	f.Field("email").(*forms.Field).ErrorRenderer = func(field forms.FormElement, errs []forms.FormError) template.HTML {
+		return template.HTML(`<div role="alert" data-toast="` + template.HTMLEscapeString(errs[0].Code) + `"></div>`)
+	}
+	if got := string(f.RenderErrors("email")); got != `<div role="alert" data-toast="required"></div>` {
+		t.Errorf("Expected the field's renderer to take precedence, got %s", got)
+	}
+	if got := f.RenderErrors("name"); !strings.HasPrefix(string(got), `<span class="error">`) {
+		t.Errorf("Expected the other fields to keep the form's renderer, got %s", got)
+	}
+}
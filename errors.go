@@ -1,13 +1,82 @@
 package forms
 
 import (
+	"encoding/json"
+	"errors"
 	"html/template"
 	"strings"
+
+	"github.com/Nigel2392/forms/validators"
+)
+
+// The Code of errors which are not a validators.ValidationError, such as plain errors passed to AddError.
+const CodeCustom = "custom"
+
+// Sentinel errors wrapped by the errors of Field.Validate, so handlers can branch on the kind of error with errors.Is.
+//
+// The message of the error is unchanged, custom messages such as ErrorMessageFieldRequired still control the text.
+var (
+	ErrRequired      = errors.New("value is required")
+	ErrTooLong       = errors.New("value is too long")
+	ErrTooShort      = errors.New("value is too short")
+	ErrNotANumber    = errors.New("value is not a number")
+	ErrInvalidChoice = errors.New("value is not one of the choices")
+	ErrInvalidFile   = errors.New("file is invalid")
 )
 
+// The sentinel errors wrapped by errors with the code.
+var codeSentinels = map[string]error{
+	"required":   ErrRequired,
+	"max_length": ErrTooLong,
+	"min_length": ErrTooShort,
+	"number":     ErrNotANumber,
+	"one_of":     ErrInvalidChoice,
+	"image":      ErrInvalidFile,
+}
+
+// sentinelError wraps a sentinel error, keeping the message of the error.
+type sentinelError struct {
+	err      error
+	sentinel error
+}
+
+func (e *sentinelError) Error() string        { return e.err.Error() }
+func (e *sentinelError) Unwrap() error        { return e.err }
+func (e *sentinelError) Is(target error) bool { return target == e.sentinel }
+
+// withSentinel wraps the sentinel error of the code of a validators.ValidationError, see codeSentinels.
+func withSentinel(err error) error {
+	var v *validators.ValidationError
+	if !errors.As(err, &v) {
+		return err
+	}
+	return wrapSentinel(err, codeSentinels[v.Code])
+}
+
+func wrapSentinel(err error, sentinel error) error {
+	if sentinel == nil || errors.Is(err, sentinel) {
+		return err
+	}
+	return &sentinelError{err: err, sentinel: sentinel}
+}
+
 type FormError struct {
 	Name     string
 	FieldErr error
+	// The code and params of the rule which failed, see validators.ValidationError.
+	Code   string
+	Params map[string]any
+}
+
+// newFormError returns the error of the named field,
+// taking the code and params from a validators.ValidationError.
+func newFormError(name string, err error) FormError {
+	var e = FormError{Name: name, FieldErr: err, Code: CodeCustom}
+	var v *validators.ValidationError
+	if errors.As(err, &v) {
+		e.Code, e.Params = v.Code, v.Params
+	}
+	return e
 }
 
 func (f FormError) Error() string {
@@ -18,37 +87,145 @@ func (f FormError) Error() string {
 	return b.String()
 }
 
+// Unwrap returns the error of the field, so errors.Is(err, ErrRequired) works on a FormError.
+func (f FormError) Unwrap() error {
+	return f.FieldErr
+}
+
+type formErrorJSON struct {
+	Name   string         `json:"name"`
+	Error  string         `json:"error"`
+	Code   string         `json:"code,omitempty"`
+	Params map[string]any `json:"params,omitempty"`
+}
+
+// MarshalJSON encodes the name, the message, and the code and params of the error.
+func (f FormError) MarshalJSON() ([]byte, error) {
+	var msg string
+	if f.FieldErr != nil {
+		msg = f.FieldErr.Error()
+	}
+	return json.Marshal(formErrorJSON{Name: f.Name, Error: msg, Code: f.Code, Params: f.Params})
+}
+
+// UnmarshalJSON decodes the error, the concrete error type is lost and becomes errors.New(message).
+func (f *FormError) UnmarshalJSON(data []byte) error {
+	var v formErrorJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*f = FormError{Name: v.Name, FieldErr: errors.New(v.Error), Code: v.Code, Params: v.Params}
+	return nil
+}
+
+func (f FormError) GobEncode() ([]byte, error) {
+	return f.MarshalJSON()
+}
+
+func (f *FormError) GobDecode(data []byte) error {
+	return f.UnmarshalJSON(data)
+}
+
+// A Translator returns the message of a validation error from its code and params,
+// or "" to keep the default message, see Form.Translator.
+type Translator func(code string, params map[string]any) string
+
+// translatedError replaces the message of an error, keeping it available to errors.Is and errors.As.
+type translatedError struct {
+	msg string
+	err error
+}
+
+func (e *translatedError) Error() string { return e.msg }
+func (e *translatedError) Unwrap() error { return e.err }
+
+// translate replaces the message of a validation error with the one of the form's Translator.
+func (f *Form) translate(err error) error {
+	if f.Translator == nil {
+		return err
+	}
+	var e = newFormError("", err)
+	if msg := f.Translator(e.Code, e.Params); msg != "" {
+		return &translatedError{msg: msg, err: err}
+	}
+	return err
+}
+
+// ErrorsJSON encodes the errors of the form as a JSON array,
+// holding the name, message, code and params of each error.
+func (f *Form) ErrorsJSON() ([]byte, error) {
+	if f == nil {
+		return []byte("[]"), nil
+	}
+	if f.Errors == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(f.Errors)
+}
+
 type FormErrors []FormError
 
 func (f *FormErrors) Add(name string, err error) {
 	if *f == nil {
 		*f = make(FormErrors, 0)
 	}
-	*f = append(*f, FormError{
-		Name:     name,
-		FieldErr: err,
-	})
+	*f = append(*f, newFormError(name, err))
+}
+
+// ErrorFormat selects what AsMap returns for each error.
+type ErrorFormat int
+
+const (
+	// The message of the error.
+	ErrorMessages ErrorFormat = iota
+	// The code of the error, e.g. "required" or "max_length".
+	ErrorCodes
+)
+
+// AsMap returns the messages or codes of the errors, keyed by the name of their field.
+func (f FormErrors) AsMap(format ErrorFormat) map[string][]string {
+	var m = make(map[string][]string, len(f))
+	for _, err := range f {
+		var v string
+		switch {
+		case format == ErrorCodes:
+			v = err.Code
+			if v == "" {
+				v = CodeCustom
+			}
+		case err.FieldErr != nil:
+			v = err.FieldErr.Error()
+		}
+		m[err.Name] = append(m[err.Name], v)
+	}
+	return m
 }
 
 func (f FormErrors) AsP() template.HTML {
 	var b strings.Builder
+	var newline = DefaultRenderConfig.newline()
 	for _, err := range f {
 		b.WriteString("<p class=\"error\">")
-		b.WriteString(err.Error())
-		b.WriteString("</p>\r\n")
+		b.WriteString(template.HTMLEscapeString(err.Error()))
+		b.WriteString("</p>")
+		b.WriteString(newline)
 	}
 	return template.HTML(b.String())
 }
 
 func (f FormErrors) AsUL() template.HTML {
 	var b strings.Builder
-	b.WriteString("<ul class=\"error\">\r\n")
+	var newline = DefaultRenderConfig.newline()
+	b.WriteString("<ul class=\"error\">")
+	b.WriteString(newline)
 	for _, err := range f {
 		b.WriteString("<li>")
-		b.WriteString(err.Error())
-		b.WriteString("</li>\r\n")
+		b.WriteString(template.HTMLEscapeString(err.Error()))
+		b.WriteString("</li>")
+		b.WriteString(newline)
 	}
-	b.WriteString("</ul>\r\n")
+	b.WriteString("</ul>")
+	b.WriteString(newline)
 	return template.HTML(b.String())
 }
 
@@ -68,3 +245,25 @@ func (f FormErrors) Error() string {
 func (f FormErrors) HasErrors() bool {
 	return len(f) > 0
 }
+
+// HasCode reports whether the named field has an error with the code, e.g. "required".
+// An empty name matches any field.
+func (f FormErrors) HasCode(name, code string) bool {
+	for _, err := range f {
+		if (name == "" || err.Name == name) && err.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// HasError reports whether the named field has an error matching target with errors.Is, e.g. ErrRequired.
+// An empty name matches any field.
+func (f FormErrors) HasError(name string, target error) bool {
+	for _, err := range f {
+		if (name == "" || err.Name == name) && errors.Is(err.FieldErr, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package forms_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+	"github.com/Nigel2392/forms/validators"
+)
+
+func TestFormErrorAndDataEncoding(t *testing.T) {
+	var errs = forms.FormErrors{{Name: "email", FieldErr: fmt.Errorf("wrapped: %w", io.EOF)}}
+	data, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `[{"name":"email","error":"wrapped: EOF"}]` {
+		t.Errorf("Expected the message to be encoded, got %s", data)
+	}
+	var decoded forms.FormErrors
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded[0].Error() != errs[0].Error() {
+		t.Errorf("Expected the error to be decoded, got %v (%v)", decoded, err)
+	}
+
+	var file = &forms.FormData{Val: []string{"a"}, FileName: "avatar.png", Reader: nopReadSeekCloser{strings.NewReader("png")}}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(struct {
+		Data   *forms.FormData
+		Errors forms.FormErrors
+	}{file, errs}); err != nil {
+		t.Fatal(err)
+	}
+	var out struct {
+		Data   *forms.FormData
+		Errors forms.FormErrors
+	}
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.Data.IsFile() || out.Data.Reader != nil || out.Data.FileName != "avatar.png" || out.Data.String() != "a" {
+		t.Errorf("Expected the file data without its reader, got %+v", out.Data)
+	}
+	if out.Errors[0].Name != "email" || out.Errors[0].FieldErr.Error() != "wrapped: EOF" {
+		t.Errorf("Expected the errors to survive gob, got %v", out.Errors)
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	var f = &forms.Form{}
+	f.AddTextField("name", forms.WithRequired())
+	f.AddTextField("nick", forms.WithValidators(validators.MaxLength(3)))
+	f.AddNumberField("age", forms.WithMax(120))
+	f.FillValues(url.Values{"nick": {"johnny"}, "age": {"130"}})
+	f.AddError("Form", errors.New("try again later"))
+	var codes = f.Errors.AsMap(forms.ErrorCodes)
+	for name, code := range map[string]string{"name": "required", "nick": "max_length", "age": "max", "Form": forms.CodeCustom} {
+		if got := codes[name]; len(got) != 1 || got[0] != code {
+			t.Errorf("Expected %s to have code %q, got %v", name, code, got)
+		}
+	}
+	if messages := f.Errors.AsMap(forms.ErrorMessages); messages["name"][0] != "Name is required" {
+		t.Errorf("Expected the messages to be kept, got %v", messages)
+	}
+	data, err := f.ErrorsJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `{"name":"nick","error":"value is too long","code":"max_length","params":{"max":3}}`) {
+		t.Errorf("Expected the code and params to be encoded, got %s", data)
+	}
+	var decoded forms.FormErrors
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded[1].Code != "max_length" || decoded[1].Params["max"] != float64(3) {
+		t.Errorf("Expected the code and params to be decoded, got %+v (%v)", decoded, err)
+	}
+
+	f.Translator = func(code string, params map[string]any) string {
+		switch code {
+		case "required":
+			return fmt.Sprintf("%s is verplicht", params["label"])
+		case "max_length":
+			return fmt.Sprintf("maximaal %v tekens", params["max"])
+		}
+		return ""
+	}
+	f.FillValues(url.Values{"nick": {"johnny"}, "age": {"130"}})
+	var messages = f.Errors.AsMap(forms.ErrorMessages)
+	if messages["name"][0] != "Name is verplicht" || messages["nick"][0] != "maximaal 3 tekens" || messages["age"][0] != "Age is too large" {
+		t.Errorf("Expected the translated messages, got %v", messages)
+	}
+	var verr *validators.ValidationError
+	if errs := f.Field("nick").Errors(); len(errs) != 1 || errs[0].Code != "max_length" || !errors.As(errs[0].FieldErr, &verr) {
+		t.Errorf("Expected the field error to keep its code, got %+v", errs)
+	}
+}
+
+func TestSentinelErrors(t *testing.T) {
+	var f = &forms.Form{}
+	var name = f.TextField("name", "name", "", "", "")
+	name.Required = true
+	name.ErrorMessageFieldRequired = "Please enter your %s"
+	name.LabelText = "name"
+	var bio = f.TextField("bio", "bio", "", "", "")
+	bio.Max = 3
+	f.NumberField("age", "age", "", "", 0)
+	f.AddSelectField("color", forms.WithValidators(validators.OneOf("red", "blue")))
+
+	if f.FillValues(url.Values{"name": {""}, "bio": {"too long"}, "age": {"x"}, "color": {"green"}}) {
+		t.Fatal("Expected the form to be invalid")
+	}
+	var tests = []struct {
+		field  string
+		target error
+	}{
+		{"name", forms.ErrRequired},
+		{"bio", forms.ErrTooLong},
+		{"age", forms.ErrNotANumber},
+		{"color", forms.ErrInvalidChoice},
+	}
+	for _, test := range tests {
+		if !f.Errors.HasError(test.field, test.target) {
+			t.Errorf("Expected the error of %s to wrap %v, got %v", test.field, test.target, f.Errors)
+		}
+	}
+	if !errors.Is(f.Errors[0], forms.ErrRequired) || !errors.Is(f.Errors[0].FieldErr, forms.ErrRequired) {
+		t.Errorf("Expected errors.Is to find ErrRequired, got %v", f.Errors[0])
+	}
+	if got := f.Errors[0].FieldErr.Error(); got != "Please enter your name" {
+		t.Errorf("Expected the custom message to be kept, got %q", got)
+	}
+	if !f.Errors.HasCode("name", "required") || f.Errors.HasCode("bio", "required") || !f.Errors.HasCode("", "one_of") {
+		t.Errorf("Unexpected HasCode results for %v", f.Errors)
+	}
+	if f.Errors.HasError("bio", forms.ErrTooShort) {
+		t.Errorf("Expected a value which is too long not to match ErrTooShort")
+	}
+}
+
+func TestFormErrorsEscaped(t *testing.T) {
+	var f = &forms.Form{}
+	f.AddTextField("c", forms.WithValidators(validators.OneOf("a", "b")))
+	f.FillValues(url.Values{"c": {"<script>alert(1)</script>"}})
+
+	var escaped = `&lt;script&gt;alert(1)&lt;/script&gt;`
+	for _, html := range []string{string(f.Errors.AsUL()), string(f.Errors.AsP())} {
+		if strings.Contains(html, "<script>") || !strings.Contains(html, escaped) {
+			t.Errorf("Expected the submitted markup to be escaped in the errors, got %s", html)
+		}
+	}
+}
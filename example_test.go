@@ -0,0 +1,42 @@
+package forms_test
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/Nigel2392/forms"
+)
+
+// Captcha is a custom field asking a simple question.
+type Captcha struct {
+	forms.BaseField
+	Question string
+	Answer   string
+}
+
+func (c *Captcha) Field() forms.ElementInterface {
+	return forms.Element(fmt.Sprintf(`<span>%s</span><input type="text" name="%s">`, c.Question, c.Name))
+}
+
+func (c *Captcha) Validate() error {
+	if c.Value().String() != c.Answer {
+		return errors.New("wrong answer")
+	}
+	return nil
+}
+
+func ExampleBaseField() {
+	var captcha = &Captcha{Question: "2 + 3 =", Answer: "5"}
+	captcha.Name = "captcha"
+	captcha.LabelText = "Are you human?"
+
+	var f = forms.Form{RenderConfig: &forms.RenderConfig{LineEnding: "\n"}}
+	f.AddFields(captcha)
+	fmt.Println(f.AsP())
+	fmt.Println(f.FillValues(url.Values{"captcha": {"4"}}), f.Field("captcha").Errors())
+	// Output:
+	// <p><label for="captcha">Are you human?</label>
+	// <span>2 + 3 =</span><input type="text" name="captcha"></p>
+	// false [captcha: wrong answer]
+}
@@ -1,13 +1,18 @@
 package forms
 
 import (
+	"bytes"
+	"encoding"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"html/template"
 	"io"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Nigel2392/forms/validators"
@@ -73,6 +78,9 @@ const (
 	TypeSubmit   = "submit"
 	TypeButton   = "button"
 	TypeReset    = "reset"
+	TypeColor    = "color"
+	TypeURL      = "url"
+	TypeTel      = "tel"
 )
 
 type Element string
@@ -92,9 +100,54 @@ type Option struct {
 }
 
 type FormData struct {
-	Val      []string
+	Val []string
+	// The sanitized name of the uploaded file, see SanitizeFilename.
 	FileName string
-	Reader   io.ReadSeekCloser
+	// The name of the uploaded file as submitted by the client, never use it as a path.
+	OriginalFileName string
+	Reader           io.ReadSeekCloser
+	// Set when the data was decoded from JSON or gob and held a file,
+	// the reader itself is never encoded.
+	HadFile bool
+	// The value parsed during validation, e.g. a float64 for number inputs, a time.Time for a DateField
+	// or a time.Duration for a DurationField. Nil when the value was not parsed.
+	//
+	// Scan prefers it over parsing Val again, rendering always uses Val. It is not encoded.
+	Typed any
+}
+
+type formDataJSON struct {
+	Val      []string `json:"values,omitempty"`
+	FileName string   `json:"file_name,omitempty"`
+	Original string   `json:"original_file_name,omitempty"`
+	HadFile  bool     `json:"had_file,omitempty"`
+}
+
+// MarshalJSON encodes the values and file name, but not the reader.
+func (f FormData) MarshalJSON() ([]byte, error) {
+	return json.Marshal(formDataJSON{
+		Val:      f.Val,
+		FileName: f.FileName,
+		Original: f.OriginalFileName,
+		HadFile:  f.HadFile || f.Reader != nil,
+	})
+}
+
+func (f *FormData) UnmarshalJSON(data []byte) error {
+	var v formDataJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*f = FormData{Val: v.Val, FileName: v.FileName, OriginalFileName: v.Original, HadFile: v.HadFile}
+	return nil
+}
+
+func (f FormData) GobEncode() ([]byte, error) {
+	return f.MarshalJSON()
+}
+
+func (f *FormData) GobDecode(data []byte) error {
+	return f.UnmarshalJSON(data)
 }
 
 // String returns the first value of the form data, or nothing.
@@ -119,7 +172,7 @@ func (f *FormData) IsFile() bool {
 	if f == nil {
 		return false
 	}
-	return f.Reader != nil && f.FileName != ""
+	return (f.Reader != nil || f.HadFile) && f.FileName != ""
 }
 
 func (f *FormData) File() (string, io.ReadSeekCloser) {
@@ -140,6 +193,8 @@ type Field struct {
 	FormValue    *FormData
 	Max          int
 	Min          int
+	MaxSet       bool
+	MinSet       bool
 	Required     bool
 	Disabled     bool
 	ReadOnly     bool
@@ -147,6 +202,88 @@ type Field struct {
 	Selected     bool
 	Options      []Option
 	Autocomplete string
+	Autofocus    bool
+	InputMode    string
+	Pattern      string
+
+	// Rendered as a disabled first option of a select, e.g. "-- choose --",
+	// selected when no other option is. Its empty value does not satisfy Required.
+	EmptyLabel string
+
+	// The value a checked checkbox or radio submits, always rendered as its value attribute.
+	// Defaults to "on" for checkboxes, radios of a group each need their own value.
+	CheckValue string
+
+	// Allow selecting multiple options.
+	Multiple bool
+
+	// Redact the value when logging the field or its form, see Form.LogSafeValues.
+	// Password fields and the CSRF field are always sensitive.
+	Sensitive bool
+
+	// The maximum size in bytes of an uploaded file, 0 means no limit.
+	// Oversized files are rejected during fill, before they reach the application.
+	// Rendered as data-max-size.
+	MaxFileSize int64
+
+	// The minimum and maximum number of submitted values, 0 means no limit.
+	// Rendered as data-min and data-max.
+	MinSelections int
+	MaxSelections int
+
+	// Decimal constraints, see Form.DecimalField.
+	// A DecimalPlaces of 0 only allows whole numbers, a negative DecimalPlaces means no limit.
+	MaxDigits     int
+	DecimalPlaces int
+	// Stripped from the start of submitted values before validation.
+	CurrencySymbol string
+	// Scan decimal values into integer destinations as minor units (e.g. cents).
+	MinorUnits bool
+
+	// Add-ons rendered before and after the input inside an input group, such as a currency symbol or an icon.
+	// Not rendered for hidden, checkbox and radio fields.
+	Prefix template.HTML
+	Suffix template.HTML
+	// Plain text add-ons, escaped when rendered. Only used when Prefix or Suffix is empty.
+	PrefixText string
+	SuffixText string
+
+	// Extra attributes rendered on the field, in sorted order.
+	Attrs map[string]string
+	// htmx attributes rendered on the field like Attrs, such as hx-post, see ValidateOnBlur.
+	HXAttrs map[string]string
+	// Set by ValidateOnBlur, the field targets the container of its errors.
+	validateOnBlur bool
+
+	// Rendered as spellcheck="true|false" only when set.
+	Spellcheck *bool
+	// Rendered as tabindex="N" only when set.
+	TabIndex *int
+
+	// The tab or section the field is rendered in, see Form.Groups.
+	Group string
+
+	// Inner text of submit, reset and plain buttons.
+	// Falls back to LabelText when empty.
+	ButtonText string
+
+	// Class of the paragraph wrapping the field, falls back to the form's DefaultWrapperClass.
+	WrapperClass string
+
+	// Render the input before its label, for checkbox and radio fields.
+	// Also enabled by the form's LabelAfter, or a wrapper class of "form-check" as used by Bootstrap.
+	LabelAfter bool
+
+	// Renders the errors of the field, overriding the ErrorRenderer of the form.
+	ErrorRenderer ErrorRenderer
+
+	// Appended to Class when the field has errors, e.g. "is-invalid".
+	// Falls back to the form's ErrorClass.
+	ErrorClass string
+
+	// HTML appended inside the label when the field is required.
+	// Falls back to the form's RequiredIndicator, then DefaultRequiredIndicator.
+	RequiredIndicator template.HTML
 
 	// FORMAT: "%s is required"
 	ErrorMessageFieldRequired string
@@ -158,14 +295,60 @@ type Field struct {
 	ErrorMessageNaN string
 
 	Validators []validators.Validator
+	// Set by NumberSelectField, the values of the options are integers matched by their canonical form.
+	intOptions bool
+	// Stop validating the field at the first error.
+	// By default all checks and Validators run, even after one of them failed, so every error is reported at once.
+	StopOnFirstFieldError bool
+	// Do not derive HTML5 validation attributes such as maxlength and pattern from Validators.
+	NoClientHints bool
+	// Validators comparing the field to other fields of its form, see FieldValidator.
+	FieldValidators []FieldValidator
+	// Validators using the context of the validation, such as the RequestInfo of Fill, see ContextValidator.
+	ContextValidators []ContextValidator
+
+	// Formats the value for display at render time only, e.g. "1234.5" as "1,234.5".
+	// Not used for number and range inputs, as browsers only accept plain numbers in their value.
+	DisplayFormatter func(string) string
+	// Computes the value of a derived field, e.g. a total price, at render time.
+	// Computed fields render as disabled inputs, are never filled from a submission,
+	// and are skipped by validation, Values and Scan.
+	Computed func(form *Form) string
+	// Normalizes submitted values during Fill, before validation, e.g. "1.234,5" to "1234.5".
+	SubmitNormalizer func(string) (string, error)
+	// Sanitizes submitted values during Fill, before the SubmitNormalizer, e.g. StripTags for rich-text textareas.
+	Sanitizer Sanitizer
+	// The submitted values before they were sanitized, e.g. for audit logging.
+	// Only set for fields with a Sanitizer.
+	RawSubmitted []string
+	// The region of phone numbers submitted without a country code, e.g. "NL", see Form.TelField.
+	DefaultRegion string
+
+	// Only validate this field when the dependency is met.
+	DependsOn *Dependency
+
+	// Hooks called before and after the built-in validation of the field.
+	// They can be used to normalize or look up values.
+	PreValidate  func(*Field) error
+	PostValidate func(*Field) error
 
 	FormErrors FormErrors
 
 	// Render function
 	RenderLabel func(f *Field) Element
 	Render      func(f *Field) Element
+
+	// Renders the input element, defaults to the widget for the field's Type.
+	Widget Widget
+
+	// The form this field was added to, used for form-level defaults.
+	form *Form
 }
 
+// DefaultRequiredIndicator is appended inside the label of required fields
+// when neither the field nor its form specify an indicator.
+var DefaultRequiredIndicator template.HTML = `<span class="required">*</span>`
+
 func NewField(name string, typ string, label string) *Field {
 	return &Field{
 		Name:      name,
@@ -174,6 +357,63 @@ func NewField(name string, typ string, label string) *Field {
 	}
 }
 
+// Clone returns a deep copy of the field.
+//
+// Functions (render functions, validators and hooks) are shared with the original,
+// the clone does not belong to any form.
+func (f *Field) Clone() *Field {
+	var c = *f
+	c.form = nil
+	if f.FormValue != nil {
+		var v = *f.FormValue
+		v.Val = append([]string(nil), f.FormValue.Val...)
+		c.FormValue = &v
+	}
+	if f.Options != nil {
+		c.Options = make([]Option, len(f.Options))
+		for i, o := range f.Options {
+			if o.Value != nil {
+				var v = *o.Value
+				v.Val = append([]string(nil), o.Value.Val...)
+				o.Value = &v
+			}
+			c.Options[i] = o
+		}
+	}
+	if f.Validators != nil {
+		c.Validators = append([]validators.Validator(nil), f.Validators...)
+	}
+	if f.FormErrors != nil {
+		c.FormErrors = append(FormErrors(nil), f.FormErrors...)
+	}
+	if f.Spellcheck != nil {
+		var b = *f.Spellcheck
+		c.Spellcheck = &b
+	}
+	if f.TabIndex != nil {
+		var i = *f.TabIndex
+		c.TabIndex = &i
+	}
+	if f.Attrs != nil {
+		c.Attrs = make(map[string]string, len(f.Attrs))
+		for k, v := range f.Attrs {
+			c.Attrs[k] = v
+		}
+	}
+	if f.HXAttrs != nil {
+		c.HXAttrs = make(map[string]string, len(f.HXAttrs))
+		for k, v := range f.HXAttrs {
+			c.HXAttrs[k] = v
+		}
+	}
+	if f.DependsOn != nil {
+		var d = *f.DependsOn
+		d.Values = append([]string(nil), f.DependsOn.Values...)
+		c.DependsOn = &d
+	}
+	return &c
+}
+
 func (f *Field) GetFile() (string, io.ReadSeekCloser) {
 	if f.FormValue == nil {
 		return "", nil
@@ -192,13 +432,15 @@ func (f *Field) IsFile() bool {
 	return f.Type == TypeFile
 }
 
+// SetFile sets the uploaded file, the filename is sanitized with SanitizeFilename.
 func (f *Field) SetFile(filename string, file io.ReadSeekCloser) error {
 	if f.Type != TypeFile {
 		return errors.New("field is not a file field")
 	}
 	f.FormValue = &FormData{
-		FileName: filename,
-		Reader:   file,
+		FileName:         SanitizeFilename(filename),
+		OriginalFileName: filename,
+		Reader:           file,
 	}
 	return nil
 }
@@ -212,7 +454,118 @@ func (f *Field) GetName() string {
 }
 
 func (f *Field) HasLabel() bool {
-	return f.LabelText != ""
+	return f.LabelText != "" && !f.isButton()
+}
+
+func (f *Field) isButton() bool {
+	switch f.Type {
+	case TypeSubmit, TypeReset, TypeButton:
+		return true
+	}
+	return false
+}
+
+// EffectiveID returns the ID used when rendering the field and its label,
+// so templates can refer to the field, e.g. in aria attributes.
+//
+// Fields without an ID fall back to their name, formatted with the form's AutoIDFormat,
+// and the form's Prefix is prepended to either.
+func (f *Field) EffectiveID() string {
+	var id = f.ID
+	if id == "" && f.Name != "" {
+		id = f.Name
+		if f.form != nil && f.form.AutoIDFormat != "" {
+			id = fmt.Sprintf(f.form.AutoIDFormat, id)
+		}
+	}
+	if id != "" && f.form != nil && f.form.Prefix != "" {
+		id = f.form.Prefix + "-" + id
+	}
+	return id
+}
+
+func (f *Field) setForm(form *Form) (previous *Form) {
+	previous, f.form = f.form, form
+	return previous
+}
+
+// class returns the class of the field, falling back to the form's DefaultFieldClass,
+// including the error class when the field has errors.
+func (f *Field) class() string {
+	var class = f.Class
+	if class == "" && f.form != nil {
+		class = f.form.DefaultFieldClass
+	}
+	if !f.HasError() {
+		return class
+	}
+	var errorClass = f.ErrorClass
+	if errorClass == "" && f.form != nil {
+		errorClass = f.form.ErrorClass
+	}
+	return joinClass(class, errorClass)
+}
+
+// labelClass returns the class of the label, falling back to the form's DefaultLabelClass.
+func (f *Field) labelClass() string {
+	if f.LabelClass == "" && f.form != nil {
+		return f.form.DefaultLabelClass
+	}
+	return f.LabelClass
+}
+
+// wrapperClass returns the class of the element wrapping the field, if any.
+func (f *Field) wrapperClass() string {
+	var class = f.WrapperClass
+	if f.form == nil {
+		return class
+	}
+	if class == "" {
+		class = f.form.DefaultWrapperClass
+	}
+	if f.HasError() {
+		return joinClass(class, f.form.ErrorWrapperClass)
+	}
+	return class
+}
+
+// labelAfter reports whether the input is rendered before its label, see LabelAfter.
+func (f *Field) labelAfter() bool {
+	if !f.isCheckable() {
+		return false
+	}
+	if f.LabelAfter || f.form != nil && f.form.LabelAfter {
+		return true
+	}
+	for _, class := range strings.Fields(f.wrapperClass()) {
+		if class == "form-check" {
+			return true
+		}
+	}
+	return false
+}
+
+func joinClass(a, b string) string {
+	if a == "" {
+		return b
+	}
+	if b == "" {
+		return a
+	}
+	return a + " " + b
+}
+
+func (f *Field) requiredIndicator() template.HTML {
+	if !f.Required {
+		return ""
+	}
+	if f.RequiredIndicator != "" {
+		return f.RequiredIndicator
+	}
+	if f.form != nil && f.form.RequiredIndicator != "" {
+		return f.form.RequiredIndicator
+	}
+	return DefaultRequiredIndicator
 }
 
 func (f *Field) Errors() []FormError {
@@ -220,20 +573,68 @@ func (f *Field) Errors() []FormError {
 }
 
 func (f *Field) AddError(err error) {
-	f.FormErrors = append(f.FormErrors, FormError{
-		Name:     f.Name,
-		FieldErr: err,
-	})
+	f.FormErrors = append(f.FormErrors, newFormError(f.Name, err))
 }
 
 func (f *Field) HasError() bool {
 	return len(f.FormErrors) > 0
 }
 
+// SetValue sets the submitted value of the field.
+//
+// For checkboxes and radios this also updates the checked state,
+// they are checked when one of the values is their CheckValue.
+// An absent value unchecks them.
 func (f *Field) SetValue(value []string) {
 	f.FormValue = &FormData{
 		Val: value,
 	}
+	if f.isCheckable() {
+		f.Checked = false
+		for _, v := range value {
+			if f.checks(v) {
+				f.Checked = true
+				break
+			}
+		}
+	}
+}
+
+// isCheckable reports whether the field is a checkbox or a radio.
+func (f *Field) isCheckable() bool {
+	return f.Type == TypeCheck || f.Type == TypeRadio
+}
+
+// checkValue returns the value a checked checkbox or radio submits.
+func (f *Field) checkValue() string {
+	if f.CheckValue == "" && f.Type == TypeCheck {
+		return "on"
+	}
+	return f.CheckValue
+}
+
+// checks reports whether a submitted value checks the checkbox or radio.
+//
+// Checkboxes without a CheckValue accept any truthy value, e.g. "on" or "true".
+func (f *Field) checks(value string) bool {
+	if f.CheckValue == "" && f.Type == TypeCheck {
+		var checked, _ = parseBool(value)
+		return checked
+	}
+	return value != "" && value == f.CheckValue
+}
+
+// isChecked reports whether a checkbox or radio is checked, either explicitly or by its value.
+func (f *Field) isChecked() bool {
+	if f.Checked {
+		return true
+	}
+	for _, v := range f.GetValue() {
+		if f.checks(v) {
+			return true
+		}
+	}
+	return false
 }
 
 func (f *Field) SetOptions(options []Option) {
@@ -244,8 +645,53 @@ func (f *Field) Value() *FormData {
 	return f.FormValue
 }
 
+// Clear resets the value of the field, leaving it in the same state as a field which was never filled.
+//
+// Checkboxes and radios are unchecked, like SetValue does for an absent value.
 func (f *Field) Clear() {
-	f.FormValue = &FormData{}
+	f.FormValue = nil
+	f.RawSubmitted = nil
+	if f.isCheckable() {
+		f.Checked = false
+	}
+}
+
+// SetMin sets the lower bound of the field, zero and negative bounds included.
+func (f *Field) SetMin(min int) {
+	f.Min = min
+	f.MinSet = true
+}
+
+// SetMax sets the upper bound of the field, zero and negative bounds included.
+func (f *Field) SetMax(max int) {
+	f.Max = max
+	f.MaxSet = true
+}
+
+// ClearMin removes the lower bound of the field.
+func (f *Field) ClearMin() {
+	f.Min = 0
+	f.MinSet = false
+}
+
+// ClearMax removes the upper bound of the field.
+func (f *Field) ClearMax() {
+	f.Max = 0
+	f.MaxSet = false
+}
+
+// HasMin reports whether the field has a lower bound.
+//
+// For backwards compatibility, a non-zero Min assigned directly also counts as a bound.
+func (f *Field) HasMin() bool {
+	return f.MinSet || f.Min != 0
+}
+
+// HasMax reports whether the field has an upper bound.
+//
+// For backwards compatibility, a non-zero Max assigned directly also counts as a bound.
+func (f *Field) HasMax() bool {
+	return f.MaxSet || f.Max != 0
 }
 
 func (f *Field) SetDisabled(disabled bool) {
@@ -260,6 +706,26 @@ func (f *Field) SetHidden(hidden bool) {
 	f.Type = TypeHidden
 }
 
+// IsDisabled reports whether the field is disabled.
+func (f *Field) IsDisabled() bool {
+	return f.Disabled
+}
+
+// IsReadOnly reports whether the field is readonly.
+func (f *Field) IsReadOnly() bool {
+	return f.ReadOnly
+}
+
+// IsHidden reports whether the field is rendered as a hidden input.
+func (f *Field) IsHidden() bool {
+	return f.Type == TypeHidden
+}
+
+// IsSensitive reports whether the value of the field is redacted when logged.
+func (f *Field) IsSensitive() bool {
+	return f.Sensitive || f.Type == TypePassword
+}
+
 func (f *Field) SetReadOnly(readOnly bool) {
 	f.ReadOnly = readOnly
 }
@@ -272,207 +738,291 @@ func (f *Field) SetSelected(selected bool) {
 	f.Selected = selected
 }
 
+// String renders the label and input element of the field.
+//
+// Password values are not rendered, log the field with LogValue instead.
 func (f *Field) String() string {
+	if f.labelAfter() {
+		return string(f.Field().HTML()) + string(f.Label().HTML())
+	}
 	return string(f.Label().HTML()) + string(f.Field().HTML())
 }
 
+var bufferPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+// Field renders the input element of the field.
+//
+// Render takes precedence over Widget, which takes precedence over the widget selected by Type.
 func (f *Field) Field() ElementInterface {
 	if f.Render != nil {
 		return f.Render(f)
 	}
-	var singleValue string
-	if f.FormValue != nil {
-		if len(f.FormValue.Val) > 0 {
-			singleValue = f.FormValue.Val[0]
+	var singleValue = f.FormValue.String()
+	if f.Computed != nil {
+		singleValue = f.Computed(f.form)
+	}
+	if f.Type == TypePassword {
+		// Submitted passwords are never rendered back into the page.
+		singleValue = ""
+	}
+	if f.DisplayFormatter != nil && singleValue != "" && f.Type != TypeNumber && f.Type != TypeRange {
+		singleValue = f.DisplayFormatter(singleValue)
+	}
+	var widget = f.Widget
+	if widget == nil {
+		widget = widgetFor(f.Type)
+	}
+	return Element(f.wrapAddons(widget.Render(WidgetContext{Field: f, Value: singleValue})))
+}
+
+// writeAttr writes the attribute, escaping its value.
+func writeAttr(b attrWriter, name, value string) {
+	b.WriteString(` `)
+	b.WriteString(name)
+	b.WriteString(`="`)
+	template.HTMLEscape(b, []byte(value))
+	b.WriteString(`"`)
+}
+
+// writeAttrs writes the attributes of the field's element.
+//
+// Attributes are written in canonical order, see BuildAttrs.
+func (f *Field) writeAttrs(b *bytes.Buffer, singleValue string) {
+	var buf [24]attr
+	var attrs = buf[:0]
+	var add = func(name, value string) {
+		attrs = append(attrs, attr{name: name, value: value})
+	}
+	var flag = func(name string, set bool) {
+		if set {
+			attrs = append(attrs, attr{name: name, boolean: true})
 		}
 	}
-	var attrStringBuilder = strings.Builder{}
 	if f.Type == "" {
-		attrStringBuilder.WriteString(` type="text"`)
+		add("type", "text")
 	} else {
-		attrStringBuilder.WriteString(` type="` + f.Type + `"`)
-	}
-	if f.ID != "" {
-		attrStringBuilder.WriteString(` id="` + f.ID + `"`)
-	} else {
-		attrStringBuilder.WriteString(` id="` + f.Name + `"`)
+		add("type", f.Type)
 	}
+	add("id", f.EffectiveID())
 	if f.Name != "" {
-		attrStringBuilder.WriteString(` name="` + f.Name + `"`)
+		add("name", f.Name)
 	}
 	if f.Placeholder != "" {
-		attrStringBuilder.WriteString(` placeholder="` + f.Placeholder + `"`)
+		add("placeholder", f.Placeholder)
 	}
-	if f.Class != "" {
-		attrStringBuilder.WriteString(` class="` + f.Class + `"`)
+	if class := f.class(); class != "" {
+		add("class", class)
 	}
-	if f.FormValue != nil && f.Type != TypeFile && singleValue != "" {
-		attrStringBuilder.WriteString(` value="` + singleValue + `"`)
+	if f.Type != TypeFile && singleValue != "" {
+		add("value", singleValue)
 	}
-	if f.Max > 0 {
-		attrStringBuilder.WriteString(` max="` + strconv.Itoa(f.Max) + `"`)
+	if f.HasMax() {
+		add("max", strconv.Itoa(f.Max))
 	}
-	if f.Min > 0 {
-		attrStringBuilder.WriteString(` min="` + strconv.Itoa(f.Min) + `"`)
+	if f.HasMin() {
+		add("min", strconv.Itoa(f.Min))
 	}
-	if f.Required {
-		attrStringBuilder.WriteString(` required`)
+	flag("required", f.Required)
+	flag("disabled", f.Disabled || f.Computed != nil)
+	flag("readonly", f.ReadOnly)
+	flag("checked", f.Checked)
+	flag("selected", f.Selected)
+	flag("multiple", f.Multiple)
+	flag("autofocus", f.Autofocus)
+	if f.Autocomplete != "" {
+		add("autocomplete", f.Autocomplete)
 	}
-	if f.Disabled {
-		attrStringBuilder.WriteString(` disabled`)
+	if f.Spellcheck != nil {
+		add("spellcheck", strconv.FormatBool(*f.Spellcheck))
 	}
-	if f.ReadOnly {
-		attrStringBuilder.WriteString(` readonly`)
+	if f.InputMode != "" {
+		add("inputmode", f.InputMode)
 	}
-	if f.Checked {
-		attrStringBuilder.WriteString(` checked`)
+	if f.Pattern != "" {
+		add("pattern", f.Pattern)
 	}
-	if f.Selected {
-		attrStringBuilder.WriteString(` selected`)
+	if f.TabIndex != nil {
+		add("tabindex", strconv.Itoa(*f.TabIndex))
 	}
-	if f.Autocomplete != "" {
-		attrStringBuilder.WriteString(` autocomplete="` + f.Autocomplete + `"`)
+	f.clientHints(add)
+	for k, v := range f.Attrs {
+		add(template.HTMLEscapeString(k), v)
 	}
-	var attrs = attrStringBuilder.String()
-	switch f.Type {
-	case "submit", "reset", "button":
-		return Element(`<button` + attrs + `>` + f.LabelText + `</button>` + "\r\n")
-	case "text", "password", "email", "number", "range", "hidden":
-		return Element(`<input` + attrs + `>` + "\r\n")
-	case "file":
-		if f.FormValue != nil && singleValue != "" {
-			var b strings.Builder
-			b.WriteString(`<p class="form-control">`)
-			b.WriteString(singleValue)
-			b.WriteString(`</p>`)
-			b.WriteString(`<input` + attrs + `>` + "\r\n")
-			return Element(b.String())
-		} else {
-			return Element(`<input` + attrs + `>` + "\r\n")
-		}
-	case "textarea":
-		if f.FormValue != nil && singleValue != "" {
-			return Element(`<textarea` + attrs + `>` + singleValue + `</textarea>` + "\r\n")
-		}
-		return Element(`<textarea` + attrs + `>` + `</textarea>` + "\r\n")
-
-	case "checkbox":
-		if f.FormValue != nil && singleValue != "" && strings.ToLower(singleValue) == "on" || strings.ToLower(singleValue) == "true" {
-			return Element(`<input` + attrs + ` checked>` + "\r\n")
-		}
-		return Element(`<input` + attrs + `>` + "\r\n")
-
-	case "radio":
-		var b = Element(`<input` + attrs + `>` + "\r\n")
-		return b
-
-	case "select":
-		var b = Element(`<select` + attrs + ">\r\n")
-		for _, option := range f.Options {
-			singleValue := ""
-			if option.Value != nil && len(option.Value.Val) > 0 {
-				singleValue = option.Value.Val[0]
-			}
-			if option.Selected {
-				b += Element(`<option value="` + singleValue + `" selected>` + option.Text + "</option>\r\n")
-				continue
-			}
-			b += Element(`<option value="` + singleValue + `">` + option.Text + "</option>\r\n")
+	for k, v := range f.HXAttrs {
+		add(template.HTMLEscapeString(k), v)
+	}
+	if _, ok := f.HXAttrs["hx-target"]; f.validateOnBlur && !ok {
+		add("hx-target", "#"+f.errorsID())
+	}
+	if f.DependsOn != nil && f.DependsOn.Field != "" {
+		add("data-depends-on", f.DependsOn.Field)
+		if len(f.DependsOn.Values) > 0 {
+			add("data-depends-values", strings.Join(f.DependsOn.Values, ","))
 		}
-		b += Element("</select>\r\n")
-		return b
 	}
-	return Element("<input" + attrs + ">\r\n")
+	if f.MinSelections > 0 {
+		add("data-min", strconv.Itoa(f.MinSelections))
+	}
+	if f.MaxSelections > 0 {
+		add("data-max", strconv.Itoa(f.MaxSelections))
+	}
+	if f.MaxFileSize > 0 {
+		add("data-max-size", strconv.FormatInt(f.MaxFileSize, 10))
+	}
+	writeCanonical(b, attrs)
 }
 
 func (f *Field) Label() ElementInterface {
 	if f.RenderLabel != nil {
 		return f.RenderLabel(f)
 	}
-	if f.LabelText == "" {
+	if f.LabelText == "" || f.isButton() {
 		return Element("")
 	}
-	var LabelClass = ""
-	if f.LabelClass != "" {
-		LabelClass = ` class="` + f.LabelClass + `"`
+	var b strings.Builder
+	b.WriteString(`<label`)
+	if class := f.labelClass(); class != "" {
+		writeAttr(&b, "class", class)
 	}
-	if f.ID == "" {
-		f.ID = f.Name
-	}
-	return Element(`<label for="` + f.ID + `"` + LabelClass + `>` + f.LabelText + `</label>` + "\r\n")
+	writeAttr(&b, "for", f.EffectiveID())
+	b.WriteString(`>`)
+	b.WriteString(f.LabelText)
+	b.WriteString(string(f.requiredIndicator()))
+	b.WriteString(`</label>`)
+	b.WriteString(f.newline())
+	return Element(b.String())
 }
 
+// Validate the field, running PreValidate and PostValidate around the built-in checks.
 func (f *Field) Validate() error {
+	if f.PreValidate != nil {
+		if err := f.PreValidate(f); err != nil {
+			return err
+		}
+	}
+	if err := f.validate(); err != nil {
+		return err
+	}
+	if f.PostValidate != nil {
+		return f.PostValidate(f)
+	}
+	return nil
+}
+
+func (f *Field) validate() error {
 	var singleValue = ""
 	if f.FormValue != nil && len(f.FormValue.Val) > 0 {
 		singleValue = f.FormValue.Val[0]
 	}
 	// VALIDATE REQUIRED
-	if f.Required && f.FormValue == nil || f.Required && f.FormValue != nil && singleValue == "" {
+	// The other checks are meaningless for a missing required value, it is the only error reported.
+	if f.Required && singleValue == "" && !f.FormValue.IsFile() {
+		var err = fmt.Errorf("%s is required", f.LabelText)
 		if f.ErrorMessageFieldRequired != "" {
-			return fmt.Errorf(f.ErrorMessageFieldRequired, f.LabelText)
+			err = fmt.Errorf(f.ErrorMessageFieldRequired, f.LabelText)
 		}
-		return fmt.Errorf("%s is required", f.LabelText)
+		return withSentinel(f.invalid("required", nil, err))
 	} else if f.FormValue == nil {
 		return nil
 	}
 
+	var errs []error
+	if err := f.validateValue(singleValue); err != nil {
+		err = withSentinel(err)
+		if f.StopOnFirstFieldError {
+			return err
+		}
+		errs = append(errs, err)
+	}
+
+	var value = f.FormValue
+	for _, validator := range f.Validators {
+		if err := validator(value); err != nil {
+			err = withSentinel(err)
+			if f.StopOnFirstFieldError {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
+
+// invalid returns the error of a built-in check as a ValidationError with the code and params of the check,
+// the label of the field is added to the params.
+func (f *Field) invalid(code string, params map[string]any, err error) error {
+	if params == nil {
+		params = make(map[string]any, 1)
+	}
+	params["label"] = f.LabelText
+	return &validators.ValidationError{Code: code, Params: params, Err: err}
+}
+
+// validateValue runs the built-in checks of the selections, the length or the bounds of the value.
+func (f *Field) validateValue(singleValue string) error {
+	// VALIDATE SELECTIONS
+	if f.MinSelections > 0 || f.MaxSelections > 0 {
+		var selected = 0
+		for _, v := range f.FormValue.Val {
+			if v != "" {
+				selected++
+			}
+		}
+		if f.MinSelections > 0 && selected < f.MinSelections {
+			return f.invalid("min_selections", map[string]any{"min": f.MinSelections}, fmt.Errorf("%s: choose at least %d options", f.LabelText, f.MinSelections))
+		}
+		if f.MaxSelections > 0 && selected > f.MaxSelections {
+			return f.invalid("max_selections", map[string]any{"max": f.MaxSelections}, fmt.Errorf("%s: choose at most %d options", f.LabelText, f.MaxSelections))
+		}
+	}
+
 	// VALIDATE LENGTH
 	switch f.Type {
 	case "number", "range":
-		var v string
-		if f.FormValue == nil && singleValue == "" {
-			v = "0"
-		} else if f.FormValue != nil {
-			v = singleValue
-		} else {
-			v = "0"
-		}
-		var i, err = strconv.Atoi(v)
-		if err != nil {
-			return fmt.Errorf("%s is not a valid number (%s)", f.LabelText, f.FormValue)
+		var i, err = strconv.ParseFloat(singleValue, 64)
+		if err != nil || math.IsNaN(i) || math.IsInf(i, 0) {
+			return f.invalid("number", nil, fmt.Errorf("%s is not a valid number (%s)", f.LabelText, f.FormValue))
 		}
+		f.FormValue.Typed = i
 
-		if f.Max > 0 && i > f.Max {
+		if f.HasMax() && i > float64(f.Max) {
+			var err = fmt.Errorf("%s is too large", f.LabelText)
 			if f.ErrorMessageFieldMax != "" {
-				return fmt.Errorf(f.ErrorMessageFieldMax, f.LabelText)
+				err = fmt.Errorf(f.ErrorMessageFieldMax, f.LabelText)
 			}
-			return fmt.Errorf("%s is too large", f.LabelText)
+			return f.invalid("max", map[string]any{"max": f.Max}, err)
 		}
 
-		if f.Min > 0 && i < f.Min {
+		if f.HasMin() && i < float64(f.Min) {
+			var err = fmt.Errorf("%s is too small", f.LabelText)
 			if f.ErrorMessageFieldMin != "" {
-				return fmt.Errorf(f.ErrorMessageFieldMin, f.LabelText)
+				err = fmt.Errorf(f.ErrorMessageFieldMin, f.LabelText)
 			}
-			return fmt.Errorf("%s is too small", f.LabelText)
+			return f.invalid("min", map[string]any{"min": f.Min}, err)
 		}
 	case "file":
 	default:
-		var v string
-		if f.FormValue != nil && singleValue != "" {
-			v = singleValue
-		} else {
-			v = singleValue
-		}
-		if f.Max > 0 && len(v) > f.Max {
+		var v = singleValue
+		if f.HasMax() && len(v) > f.Max {
+			var err = fmt.Errorf("%s is too long by %d characters", f.LabelText, len(v)-f.Max)
 			if f.ErrorMessageFieldMax != "" {
-				return fmt.Errorf(f.ErrorMessageFieldMax, f.LabelText)
+				err = fmt.Errorf(f.ErrorMessageFieldMax, f.LabelText)
 			}
-			return fmt.Errorf("%s is too long by %d characters", f.LabelText, len(v)-f.Max)
+			return f.invalid("max_length", map[string]any{"max": f.Max}, err)
 		}
-		if f.Min != 0 && len(v) < f.Min {
+		if f.HasMin() && len(v) < f.Min {
+			var err = fmt.Errorf("%s is too short by %d characters", f.LabelText, f.Min-len(v))
 			if f.ErrorMessageFieldMin != "" {
-				return fmt.Errorf(f.ErrorMessageFieldMin, f.LabelText)
-			}
-			return fmt.Errorf("%s is too short by %d characters", f.LabelText, f.Min-len(v))
-		}
-	}
-
-	if f.Validators != nil {
-		for _, validator := range f.Validators {
-			if err := validator(f.FormValue); err != nil {
-				return err
+				err = fmt.Errorf(f.ErrorMessageFieldMin, f.LabelText)
 			}
+			return f.invalid("min_length", map[string]any{"min": f.Min}, err)
 		}
 	}
 
@@ -489,8 +1039,30 @@ func (f *Field) Validate() error {
 // `form:"min:VALUE,(params)"` - The minimum length of the field
 // `form:"max:VALUE,(params)"` - The maximum length of the field
 // `form:"regex:VALUE,(params)"` - The regex to validate the field against
-
-func GenerateFieldsFromStruct(s interface{}) ([]*Field, error) {
+// `form:"readonly"` - Whether the field is readonly, ScanStruct will not write into it
+// `form:"disabled"` - Whether the field is disabled, ScanStruct will not write into it
+// `form:"hidden"` - Whether the field is rendered as a hidden input
+// `form:"-"` - Skip the field entirely
+//
+// The key is separated from the value by the first colon, values may be quoted to contain colons or semicolons:
+// `form:"placeholder:'Enter URL: https://example.com'; regex:^a\\;b$"`
+// `form:"autofocus"` - Whether the field should be focused on page load
+// `form:"spellcheck:VALUE"` - Whether spellchecking is enabled (true/false)
+// `form:"inputmode:VALUE"` - The virtual keyboard hint (numeric, decimal, email, tel...)
+// `form:"tabindex:VALUE"` - The tab order of the field
+// `form:"group:VALUE"` - The tab or section of the field, see Form.Groups
+// `form:"validate:email,notempty"` - Named validators: email, url, notempty, json, hexcolor
+// `form:"oneof:a|b|c"` - The allowed values of the field
+// `form:"minval:VALUE"` - The minimum numeric value of the field
+// `form:"maxval:VALUE"` - The maximum numeric value of the field
+// `form:"minlen:VALUE"` - The minimum length of the value
+// `form:"maxlen:VALUE"` - The maximum length of the value
+//
+// Other keys are passed to the handler registered for them with RegisterTagHandler, or ignored.
+//
+// Struct fields without a form tag are skipped, unless they are named by the WithTagFallback,
+// WithNameMapper or IncludeUntagged options.
+func GenerateFieldsFromStruct(s interface{}, opts ...StructOption) ([]*Field, error) {
 	var fields = make([]*Field, 0)
 	var value = reflect.ValueOf(s)
 	var typ = reflect.TypeOf(s)
@@ -501,67 +1073,120 @@ func GenerateFieldsFromStruct(s interface{}) ([]*Field, error) {
 	if typ.Kind() != reflect.Struct {
 		return fields, errors.New("not a struct")
 	}
+	var blueprints, err = structBlueprints(typ, newStructConfig(opts))
+	if err != nil {
+		return fields, err
+	}
+	for _, bp := range blueprints {
+		var f = bp.field.Clone()
+		if err := bp.setValue(f, value.Field(bp.index)); err != nil {
+			return fields, fmt.Errorf("field %s (%s): %w", typ.Field(bp.index).Name, typ.Field(bp.index).Type, err)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// GenerateFieldMapFromStruct generates fields like GenerateFieldsFromStruct,
+// additionally returning them in a map keyed by field name for easy customization.
+func GenerateFieldMapFromStruct(s any, opts ...StructOption) (map[string]*Field, []*Field, error) {
+	var fields, err = GenerateFieldsFromStruct(s, opts...)
+	if err != nil {
+		return nil, fields, err
+	}
+	var m = make(map[string]*Field, len(fields))
+	for _, f := range fields {
+		m[f.Name] = f
+	}
+	return m, fields, nil
+}
+
+// A blueprint of a field generated from a struct field.
+//
+// Blueprints hold everything derived from the struct type and its tags,
+// the current values are read from the struct each time fields are generated.
+type blueprint struct {
+	index int
+	field *Field
+	// Generate select options from the slice value.
+	options bool
+	// Generate select options from the value's OptionLister implementation.
+	choices bool
+}
+
+// Blueprints are cached per struct type, see ClearStructCache.
+var structCache sync.Map
+
+// ClearStructCache clears the cached blueprints of all struct types.
+func ClearStructCache() {
+	structCache.Range(func(key, _ any) bool {
+		structCache.Delete(key)
+		return true
+	})
+}
+
+// structCacheKey identifies the blueprints of a struct type parsed with the given options.
+type structCacheKey struct {
+	typ             reflect.Type
+	tagFallback     string
+	includeUntagged bool
+}
+
+// structBlueprints returns the blueprints of the struct type,
+// blueprints parsed with a name mapper are not cached, as functions cannot be compared.
+func structBlueprints(typ reflect.Type, cfg structConfig) ([]blueprint, error) {
+	if cfg.nameMapper != nil {
+		return parseStruct(typ, cfg)
+	}
+	var key = structCacheKey{typ: typ, tagFallback: cfg.tagFallback, includeUntagged: cfg.includeUntagged}
+	if cached, ok := structCache.Load(key); ok {
+		return cached.([]blueprint), nil
+	}
+	var blueprints, err = parseStruct(typ, cfg)
+	if err != nil {
+		return nil, err
+	}
+	var cached, _ = structCache.LoadOrStore(key, blueprints)
+	return cached.([]blueprint), nil
+}
+
+func parseStruct(typ reflect.Type, cfg structConfig) ([]blueprint, error) {
+	var blueprints = make([]blueprint, 0, typ.NumField())
 	for i := 0; i < typ.NumField(); i++ {
 		var field = typ.Field(i)
-		var value = value.Field(i)
-		var name = field.Tag.Get("form")
-		if name == "" {
+		var tag, hasTag = field.Tag.Lookup("form")
+		var fieldName = field.Name
+		if !hasTag || tag == "" {
+			fieldName = cfg.fallbackName(field)
+		}
+		if tag == "-" || fieldName == "" {
 			continue
 		}
-		var pieces = strings.Split(name, ";")
-		var f = Field{}
-		f.Name = field.Name
-		for _, piece := range pieces {
-			var parts = strings.Split(piece, ":")
-			if len(parts) < 2 {
-				continue
+		var pairs, err = parseTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		var f = &Field{}
+		f.Name = fieldName
+		f.LabelText = DefaultLabeler(field.Name)
+		for _, pair := range pairs {
+			if err := applyTag(f, pair.key, pair.value); err != nil {
+				return nil, fmt.Errorf("field %s: tag segment %q: %w", field.Name, pair.raw, err)
 			}
+		}
 
-			parts[0] = strings.TrimSpace(parts[0])
-			parts[1] = strings.TrimSpace(parts[1])
-
-			if !value.CanInterface() {
-				continue
-			}
-			// Check if it implements a FormValue interface
-			if value.Interface() != nil {
-				var fv = value.Interface()
-				f.FormValue = switchTyp(fv)
-			}
-			switch strings.ToLower(parts[0]) {
-			case "type":
-				f.Type = parts[1]
-			case "label":
-				f.LabelText = parts[1]
-			case "placeholder":
-				f.Placeholder = parts[1]
-			case "class":
-				f.Class = parts[1]
-			case "required":
-				f.Required = true
-			case "min":
-				var i, err = strconv.Atoi(parts[1])
-				if err != nil {
-					return fields, err
-				}
-				f.Min = i
-			case "max":
-				var i, err = strconv.Atoi(parts[1])
-				if err != nil {
-					return fields, err
-				}
-				f.Max = i
-			case "regex":
-				if f.Validators == nil {
-					f.Validators = make([]validators.Validator, 0)
-				}
-				f.Validators = append(f.Validators, validators.Regex(parts[1], f.Required))
+		var bp = blueprint{index: i, field: f}
+		if field.Type.Implements(optionListerType) {
+			bp.choices = true
+			if f.Type == "" {
+				f.Type = "select"
 			}
 		}
-
+		if f.Type == "" && implementsText(field.Type) {
+			f.Type = "text"
+		}
 		if f.Type == "" {
-			var kind = value.Kind()
-			switch kind {
+			switch field.Type.Kind() {
 			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 				f.Type = "number"
 			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
@@ -573,52 +1198,169 @@ func GenerateFieldsFromStruct(s interface{}) ([]*Field, error) {
 			case reflect.String:
 				f.Type = "text"
 			case reflect.Slice:
-				f.Type = "select"
-				// Set the options
-				var options = make([]Option, 0)
-				for i := 0; i < value.Len(); i++ {
-					var v = value.Index(i)
-					var o = Option{}
-					if v.CanInterface() {
-						var fv = v.Interface()
-						o.Value = switchTyp(fv)
-						var v = switchTyp(fv).Value()
-						if len(v) > 0 {
-							o.Text = v[0]
-						}
-					}
-					options = append(options, o)
+				if !representable(field.Type.Elem()) {
+					return nil, fmt.Errorf("field %s: slices of %s are not supported, the element type must be a primitive or implement forms.Valuer", field.Name, field.Type.Elem())
 				}
-				f.Options = options
-				f.FormValue = &FormData{Val: []string{}}
+				f.Type = "select"
+				bp.options = true
 			}
 		}
 
-		fields = append(fields, &f)
+		blueprints = append(blueprints, bp)
 	}
-	return fields, nil
+	return blueprints, nil
+}
+
+var (
+	valuerType        = reflect.TypeOf((*Valuer)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	optionListerType  = reflect.TypeOf((*OptionLister)(nil)).Elem()
+)
+
+// implementsText reports whether values of the type convert themselves to text,
+// in which case they are rendered as a text input regardless of their kind.
+func implementsText(typ reflect.Type) bool {
+	return typ.Implements(valuerType) || typ.Implements(textMarshalerType)
+}
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// representable reports whether values of the type can be converted to text by switchTyp.
+func representable(typ reflect.Type) bool {
+	if implementsText(typ) || typ.Implements(stringerType) || typ == reflect.TypeOf(time.Time{}) {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Bool, reflect.String:
+		return true
+	case reflect.Ptr:
+		return representable(typ.Elem())
+	}
+	return false
 }
 
-func switchTyp(t any) *FormData {
+// setValue sets the value of a field generated from the blueprint to the current value of the struct field.
+func (bp blueprint) setValue(f *Field, value reflect.Value) error {
+	if !value.CanInterface() {
+		return nil
+	}
+	if bp.choices {
+		return setChoices(f, value.Interface())
+	}
+	if f.Type == TypeCheck && value.Kind() == reflect.Bool {
+		f.Checked = value.Bool()
+		return nil
+	}
+	if !bp.options {
+		var v, err = switchTyp(value.Interface())
+		if err != nil {
+			return err
+		}
+		f.FormValue = v
+		return nil
+	}
+	// Set the options
+	var options = make([]Option, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		var v = value.Index(i)
+		var o = Option{}
+		if v.CanInterface() {
+			var fv, err = switchTyp(v.Interface())
+			if err != nil {
+				return err
+			}
+			o.Value = fv
+			var v = o.Value.Value()
+			if len(v) > 0 {
+				o.Text = v[0]
+			}
+		}
+		options = append(options, o)
+	}
+	f.Options = options
+	f.FormValue = nil
+	return nil
+}
+
+// setChoices sets the options of the field to the choices of the value,
+// and selects the option matching the current value.
+func setChoices(f *Field, value any) error {
+	var current, err = switchTyp(value)
+	if err != nil {
+		return err
+	}
+	var choices = value.(OptionLister).Choices()
+	var options = make([]Option, len(choices))
+	var allowed = make([]string, 0, len(choices))
+	for i, o := range choices {
+		o.Selected = o.Value.String() == current.String()
+		options[i] = o
+		if o.Value != nil {
+			allowed = append(allowed, o.Value.Val...)
+		}
+	}
+	f.Options = options
+	f.FormValue = current
+	f.Validators = append(f.Validators, validators.OneOf(allowed...))
+	return nil
+}
+
+// switchTyp converts a value into form data.
+//
+// Primitive types are formatted directly, time.Time is formatted as RFC3339.
+// Any other type is converted by the first interface it implements, in order of precedence:
+//
+//	Valuer > encoding.TextMarshaler > fmt.Stringer > kind fallback
+//
+// Named types without any of the supported interfaces are converted by their underlying kind,
+// an error is returned for types which cannot be represented as a string.
+func switchTyp(t any) (*FormData, error) {
 	switch val := t.(type) {
 	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
-		return NewValue(fmt.Sprintf("%d", val))
-	case float32, float64:
-		return NewValue(fmt.Sprintf("%f", val))
+		return NewValue(fmt.Sprintf("%d", val)), nil
+	case float32:
+		return NewValue(strconv.FormatFloat(float64(val), 'f', -1, 32)), nil
+	case float64:
+		return NewValue(strconv.FormatFloat(val, 'f', -1, 64)), nil
 	case bool:
-		return NewValue(fmt.Sprintf("%t", val))
+		return NewValue(fmt.Sprintf("%t", val)), nil
 	case string:
-		return NewValue(val)
+		return NewValue(val), nil
 	case []byte:
-		return NewValue(string(val))
-	case Valuer:
-		return NewValue(val.StringValue())
+		return NewValue(string(val)), nil
 	case time.Time:
-		return NewValue(val.Format(time.RFC3339))
+		return NewValue(val.Format(time.RFC3339)), nil
+	case Valuer:
+		return NewValue(val.StringValue()), nil
+	case encoding.TextMarshaler:
+		var b, err = val.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return NewValue(string(b)), nil
 	case fmt.Stringer:
-		return NewValue(val.String())
-	default:
-		panic(fmt.Sprintf("unsupported type %T must implement the forms.Valuer interface.", val))
-		// return NewValue(fmt.Sprintf("%v", val))
+		return NewValue(val.String()), nil
+	}
+
+	var v = reflect.ValueOf(t)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return NewValue(strconv.FormatInt(v.Int(), 10)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return NewValue(strconv.FormatUint(v.Uint(), 10)), nil
+	case reflect.Float32, reflect.Float64:
+		return NewValue(strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())), nil
+	case reflect.Bool:
+		return NewValue(strconv.FormatBool(v.Bool())), nil
+	case reflect.String:
+		return NewValue(v.String()), nil
+	case reflect.Ptr:
+		if v.IsNil() {
+			return NewValue(""), nil
+		}
+		return switchTyp(v.Elem().Interface())
 	}
+	return nil, fmt.Errorf("unsupported type %T must implement the forms.Valuer interface", t)
 }
@@ -0,0 +1,220 @@
+package forms_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+	"github.com/Nigel2392/forms/validators"
+)
+
+func TestRequiredIndicatorAndButtonText(t *testing.T) {
+	var f = forms.Form{}
+	var email = f.EmailField("email", "email", "", "", "")
+	email.Required = true
+	var submit = f.SubmitButton("submit", "submit", "", "")
+	submit.ButtonText = "Log in"
+
+	var expected = "<label for=\"email\">Email<span class=\"required\">*</span></label>\r\n<input type=\"email\" id=\"email\" name=\"email\" required>\r\n"
+	if email.String() != expected {
+		t.Errorf("Expected \n%q\ngot \n%q", expected, email.String())
+	}
+
+	expected = "<button type=\"submit\" id=\"submit\" name=\"submit\">Log in</button>\r\n"
+	if submit.String() != expected {
+		t.Errorf("Expected \n%q\ngot \n%q", expected, submit.String())
+	}
+
+	f.RequiredIndicator = `<abbr title="required">*</abbr>`
+	expected = "<label for=\"email\">Email<abbr title=\"required\">*</abbr></label>\r\n"
+	if email.Label().String() != expected {
+		t.Errorf("Expected \n%q\ngot \n%q", expected, email.Label().String())
+	}
+}
+
+func TestFocusAndKeyboardAttributes(t *testing.T) {
+	type Pin struct {
+		Code string `form:"label:Code; autofocus; inputmode:numeric; spellcheck:false; tabindex:2"`
+	}
+	fields, err := forms.GenerateFieldsFromStruct(Pin{Code: "1234"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var field = fields[0]
+	if !field.Autofocus || field.InputMode != "numeric" {
+		t.Fatalf("Expected autofocus and inputmode to be set, got %v and %q", field.Autofocus, field.InputMode)
+	}
+	var expected = "<input type=\"text\" id=\"Code\" name=\"Code\" value=\"1234\" autofocus inputmode=\"numeric\" spellcheck=\"false\" tabindex=\"2\">\r\n"
+	if field.Field().String() != expected {
+		t.Errorf("Expected \n%q\ngot \n%q", expected, field.Field().String())
+	}
+}
+
+func TestLabelDoesNotMutateField(t *testing.T) {
+	var field = forms.NewField("email", forms.TypeEmail, "Email")
+	var first = field.Label().String()
+	var second = field.Label().String()
+	if first != second || field.ID != "" {
+		t.Errorf("Expected rendering the label to be idempotent and leave ID unset, got ID %q", field.ID)
+	}
+	if !strings.Contains(field.Field().String(), `id="email"`) || !strings.Contains(first, `for="email"`) {
+		t.Errorf("Expected label and field to fall back to the name as ID")
+	}
+
+	var f = forms.Form{}
+	var unnamed = forms.NewField("", forms.TypeText, "Unnamed")
+	f.AddFields(forms.NewField("first", forms.TypeText, "First"), unnamed)
+	if unnamed.ID != "field_1" || !strings.Contains(unnamed.Label().String(), `for="field_1"`) {
+		t.Errorf("Expected a stable fallback ID, got %q", unnamed.ID)
+	}
+}
+
+func TestZeroAndNegativeBounds(t *testing.T) {
+	var f = forms.Form{}
+	var offset = f.NumberField("offset", "offset", "", "", 0)
+	offset.SetMax(0)
+	offset.SetMin(-10)
+
+	var rendered = offset.Field().String()
+	if !strings.Contains(rendered, `max="0"`) || !strings.Contains(rendered, `min="-10"`) {
+		t.Errorf("Expected zero and negative bounds to render, got %s", rendered)
+	}
+	for value, valid := range map[string]bool{"-3": true, "0": true, "1": false, "-11": false} {
+		offset.SetValue([]string{value})
+		if (offset.Validate() == nil) != valid {
+			t.Errorf("Expected %s to be valid: %v", value, valid)
+		}
+	}
+
+	type Settings struct {
+		Note string `form:"max:0"`
+	}
+	fields, err := forms.GenerateFieldsFromStruct(Settings{Note: "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fields[0].HasMax() || fields[0].Validate() == nil {
+		t.Errorf("Expected max:0 to be enforced")
+	}
+}
+
+func TestErrorClasses(t *testing.T) {
+	var f = forms.Form{ErrorClass: "is-invalid", ErrorWrapperClass: "has-error"}
+	f.AddFields(
+		forms.New("name", forms.WithClass("form-control"), forms.WithRequired()),
+		forms.New("email", forms.WithType(forms.TypeEmail), forms.WithValue("a@b.c")),
+		forms.New("age", forms.WithType(forms.TypeNumber), forms.WithRequired()),
+	)
+	f.Field("age").(*forms.Field).ErrorClass = "error"
+	if f.Validate() {
+		t.Fatal("Expected the form to be invalid")
+	}
+	var html = string(f.AsP())
+	if !strings.Contains(html, `<p class="has-error"><label for="name">`) || !strings.Contains(html, `class="form-control is-invalid"`) {
+		t.Errorf("Expected the form's error classes on the invalid field, got \n%s", html)
+	}
+	if !strings.Contains(html, `<p><label for="email">`) || strings.Contains(html, `id="email" name="email" class=`) {
+		t.Errorf("Expected no error classes on the valid field, got \n%s", html)
+	}
+	if !strings.Contains(html, `id="age" name="age" class="error"`) {
+		t.Errorf("Expected the field's error class to take precedence, got \n%s", html)
+	}
+}
+
+func TestMinMaxSelections(t *testing.T) {
+	var field = forms.New("toppings", forms.WithType(forms.TypeSelect), forms.WithMultiple(), forms.WithSelections(2, 3), forms.WithOptions([]forms.Option{
+		{Value: forms.NewValue("cheese"), Text: "Cheese"},
+		{Value: forms.NewValue("ham"), Text: "Ham"},
+		{Value: forms.NewValue("olives"), Text: "Olives"},
+		{Value: forms.NewValue("onion"), Text: "Onion"},
+	}))
+	var html = field.Field().String()
+	if !strings.Contains(html, ` data-max="3" data-min="2" multiple>`) {
+		t.Errorf("Expected multiple and data-min/data-max attributes, got %s", html)
+	}
+
+	field.SetValue([]string{"cheese"})
+	if err := field.Validate(); err == nil || !strings.Contains(err.Error(), "choose at least 2 options") {
+		t.Errorf("Expected too few selections to be rejected, got %v", err)
+	}
+	field.SetValue([]string{"cheese", "ham", "olives", "onion"})
+	if err := field.Validate(); err == nil || !strings.Contains(err.Error(), "choose at most 3 options") {
+		t.Errorf("Expected too many selections to be rejected, got %v", err)
+	}
+	field.SetValue([]string{"cheese", "ham"})
+	if err := field.Validate(); err != nil {
+		t.Errorf("Expected 2 selections to be valid, got %v", err)
+	}
+}
+
+func TestFormDefaultClasses(t *testing.T) {
+	var f = forms.Form{DefaultFieldClass: "form-control", DefaultLabelClass: "form-label", DefaultWrapperClass: "mb-3", ErrorWrapperClass: "has-error"}
+	f.TextField("name", "name", "", "", "")
+	var email = f.EmailField("email", "email", "email-input", "", "")
+	email.LabelClass = "visually-hidden"
+	email.WrapperClass = "col"
+	email.Required = true
+
+	f.Validate()
+	var expected = "<p class=\"mb-3\"><label class=\"form-label\" for=\"name\">Name</label>\r\n" +
+		"<input type=\"text\" id=\"name\" name=\"name\" class=\"form-control\">\r\n</p>" +
+		"<ul class=\"errorlist\">\r\n<li>Email is required</li>\r\n</ul>\r\n" +
+		"<p class=\"col has-error\"><label class=\"visually-hidden\" for=\"email\">Email<span class=\"required\">*</span></label>\r\n" +
+		"<input type=\"email\" id=\"email\" name=\"email\" class=\"email-input\" required>\r\n</p>"
+	if string(f.AsP()) != expected {
+		t.Errorf("Expected \n%q\ngot \n%q", expected, f.AsP())
+	}
+}
+
+func TestSelectEmptyLabel(t *testing.T) {
+	var f = forms.Form{}
+	var field = f.AddSelectField("size", forms.WithRequired(), forms.WithEmptyLabel("-- choose --"), forms.WithOptions([]forms.Option{
+		{Value: forms.NewValue("s"), Text: "Small"},
+		{Value: forms.NewValue("l"), Text: "Large"},
+	}))
+	if !strings.Contains(field.Field().String(), "required>\r\n<option value=\"\" disabled selected>-- choose --</option>\r\n<option value=\"s\">") {
+		t.Errorf("Expected a selected placeholder option first, got \n%s", field.Field())
+	}
+
+	if f.FillValues(url.Values{"size": {""}}) {
+		t.Errorf("Expected the empty placeholder value to fail required validation")
+	}
+	if !f.FillValues(url.Values{"size": {"l"}}) {
+		t.Fatalf("Expected a chosen option to be valid, got %v", f.Errors)
+	}
+	if strings.Contains(field.Field().String(), "disabled selected") {
+		t.Errorf("Expected the placeholder not to be selected once an option is chosen, got \n%s", field.Field())
+	}
+}
+
+func TestColorAndURLFields(t *testing.T) {
+	var tests = []struct {
+		color, url string
+		valid      bool
+	}{
+		{"#1a2b3c", "https://example.com/path?q=1", true},
+		{"#FFFFFF", "http://localhost:8080", true},
+		{"", "", true},
+		{"#fff", "https://example.com", false},
+		{"1a2b3c", "https://example.com", false},
+		{"#1a2b3g", "https://example.com", false},
+		{"#1a2b3c", "example.com", false},
+		{"#1a2b3c", "javascript:alert(1)", false},
+		{"#1a2b3c", "ftp://example.com", false},
+	}
+	for _, test := range tests {
+		var f = forms.Form{}
+		f.ColorField("color", "", "", "#000000")
+		f.URLField("website", "", "", "https://", "")
+		if valid := f.FillValues(url.Values{"color": {test.color}, "website": {test.url}}); valid != test.valid {
+			t.Errorf("color %q, url %q: expected valid to be %v, got %v (%v)", test.color, test.url, test.valid, valid, f.Errors)
+		}
+	}
+
+	var field = forms.New("accent", forms.WithValidators(validators.HexColor(true)))
+	field.SetValue([]string{"#abc"})
+	if err := field.Validate(); err != nil {
+		t.Errorf("Expected the short format to be accepted, got %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package forms
+
+import (
+	"html/template"
+	"strings"
+)
+
+// A FieldSet groups related fields by name, rendering them inside of a <fieldset> with a <legend>.
+type FieldSet struct {
+	Legend   string
+	Class    string
+	Fields   []string
+	Disabled bool
+}
+
+// AddFieldSet adds fieldsets to the form.
+//
+// Fields which are not part of any fieldset are rendered after all fieldsets.
+func (f *Form) AddFieldSet(sets ...*FieldSet) {
+	if f == nil {
+		return
+	}
+	if f.FieldSets == nil {
+		f.FieldSets = make([]*FieldSet, 0, len(sets))
+	}
+	for _, set := range sets {
+		f.FieldSets = append(f.FieldSets, set)
+		if set.Disabled {
+			f.disableFieldSet(set)
+		}
+	}
+}
+
+// DisableFieldSet disables the fieldset with the given legend, and all the fields it contains.
+func (f *Form) DisableFieldSet(legend string) {
+	if f == nil {
+		return
+	}
+	for _, set := range f.FieldSets {
+		if set.Legend == legend {
+			set.Disabled = true
+			f.disableFieldSet(set)
+		}
+	}
+}
+
+func (f *Form) disableFieldSet(set *FieldSet) {
+	for _, name := range set.Fields {
+		var field = f.Field(name)
+		if field != nil {
+			field.SetDisabled(true)
+		}
+	}
+}
+
+// Fields of the form which are not part of any fieldset, in declaration order.
+func (f *Form) unsetFields() []FormElement {
+	if len(f.FieldSets) == 0 {
+		return f.Fields
+	}
+	var inSet = make(map[string]bool)
+	for _, set := range f.FieldSets {
+		for _, name := range set.Fields {
+			inSet[f.nameKey(name)] = true
+		}
+	}
+	var fields = make([]FormElement, 0, len(f.Fields))
+	for _, field := range f.Fields {
+		if !inSet[f.nameKey(field.GetName())] {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// Render the fieldset with the given write function for each contained field.
+func (set *FieldSet) render(b *strings.Builder, f *Form, write func(*strings.Builder, FormElement)) {
+	b.WriteString(`<fieldset`)
+	if set.Class != "" {
+		writeAttr(b, "class", set.Class)
+	}
+	if set.Disabled {
+		b.WriteString(` disabled`)
+	}
+	var newline = f.renderConfig().newline()
+	b.WriteString(">")
+	b.WriteString(newline)
+	if set.Legend != "" {
+		b.WriteString(`<legend>`)
+		b.WriteString(template.HTMLEscapeString(set.Legend))
+		b.WriteString("</legend>")
+		b.WriteString(newline)
+	}
+	for _, name := range set.Fields {
+		var field = f.Field(name)
+		if field != nil {
+			write(b, field)
+		}
+	}
+	b.WriteString("</fieldset>")
+	b.WriteString(newline)
+}
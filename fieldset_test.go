@@ -0,0 +1,51 @@
+package forms_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestFieldSet(t *testing.T) {
+	var f = forms.Form{}
+	f.TextField("name", "name", "", "", "")
+	f.TextField("street", "street", "", "", "")
+	f.TextField("city", "city", "", "", "")
+	f.AddFieldSet(&forms.FieldSet{Legend: "Address", Class: "address", Fields: []string{"street", "city"}})
+	f.DisableFieldSet("Address")
+
+	var rendered = string(f.AsP())
+	var fieldset = strings.Index(rendered, `<fieldset class="address" disabled>`+"\r\n<legend>Address</legend>")
+	var street = strings.Index(rendered, `name="street"`)
+	var end = strings.Index(rendered, `</fieldset>`)
+	var name = strings.Index(rendered, `name="name"`)
+	if fieldset == -1 || !(fieldset < street && street < end && end < name) {
+		t.Errorf("Expected fieldset to be rendered before the remaining fields, got %s", rendered)
+	}
+	if !strings.Contains(f.Field("city").Field().String(), "disabled") {
+		t.Errorf("Expected fields inside a disabled fieldset to be disabled")
+	}
+}
+
+func TestClassesEscaped(t *testing.T) {
+	var f = &forms.Form{DefaultLabelClass: `a" onclick="x`, RenderConfig: &forms.RenderConfig{Compact: true}}
+	f.AddTextField("name", forms.WithID(`n"1`))
+	var field = f.Field("name").(*forms.Field)
+	field.WrapperClass = `w"><script>`
+	f.AddTextField("bio")
+	f.Field("bio").(*forms.Field).LabelClass = `b'"`
+	f.AddFieldSet(&forms.FieldSet{Class: `s" hidden="`, Fields: []string{"name", "bio"}})
+
+	var html = string(f.AsP())
+	for _, want := range []string{
+		`<fieldset class="s&#34; hidden=&#34;">`,
+		`<p class="w&#34;&gt;&lt;script&gt;">`,
+		`<label class="a&#34; onclick=&#34;x" for="n&#34;1">`,
+		`<label class="b&#39;&#34;" for="bio">`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected %s in \n%s", want, html)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package forms
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrFileTooLarge is wrapped by the error added to a file field when the upload exceeds its MaxFileSize.
+var ErrFileTooLarge = errors.New("file is too large")
+
+// A FileHook inspects an uploaded file during Fill, e.g. to check magic bytes or call a scanning service.
+//
+// Returning an error attaches it to the field and marks the form invalid.
+type FileHook func(field string, filename string, r io.ReadSeeker) error
+
+// runFileHook runs the form's FileHook for a file which was just set on the field.
+//
+// The file is rewound before and after the hook.
+func (f *Form) runFileHook(field FormElement, filename string, r io.ReadSeeker) {
+	if f.FileHook == nil || r == nil {
+		return
+	}
+	var err error
+	if _, err = r.Seek(0, io.SeekStart); err == nil {
+		err = f.FileHook(field.GetName(), filename, r)
+		if _, seekErr := r.Seek(0, io.SeekStart); err == nil {
+			err = seekErr
+		}
+	}
+	if err != nil {
+		f.rejectFile(field, err)
+	}
+}
+
+// maxFileSize returns the MaxFileSize of the field, 0 means no limit.
+func maxFileSize(field FormElement) int64 {
+	if fld, ok := asField(field); ok && fld.MaxFileSize > 0 {
+		return fld.MaxFileSize
+	}
+	return 0
+}
+
+func fileTooLarge(max int64) error {
+	return fmt.Errorf("%w: the maximum size is %d bytes", ErrFileTooLarge, max)
+}
+
+// rejectFile adds the error to the form and the field, and marks the form invalid until it is validated.
+//
+// The error wraps ErrInvalidFile.
+func (f *Form) rejectFile(field FormElement, err error) {
+	f.fileRejected = true
+	err = wrapSentinel(err, ErrInvalidFile)
+	f.AddError(field.GetName(), err)
+	field.AddError(err)
+}
+
+// SHA256Digest is a FileHook storing the hex encoded SHA-256 digest of each uploaded file in FileDigests,
+// keyed by field name, e.g. for deduplication:
+//
+//	form.FileHook = form.SHA256Digest
+func (f *Form) SHA256Digest(field string, filename string, r io.ReadSeeker) error {
+	if f == nil {
+		return ErrNilForm
+	}
+	var h = sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return err
+	}
+	if f.FileDigests == nil {
+		f.FileDigests = make(map[string]string)
+	}
+	f.FileDigests[field] = hex.EncodeToString(h.Sum(nil))
+	return nil
+}
@@ -0,0 +1,77 @@
+package forms_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+	"github.com/Nigel2392/router/v3/request"
+)
+
+func TestFileHook(t *testing.T) {
+	var f = forms.Form{}
+	f.FileField("upload", "upload", "", "", "")
+	f.FileHook = f.SHA256Digest
+	if !f.Fill(&request.Request{Request: newUploadRequest("upload", "hello")}) {
+		t.Fatalf("Expected the form to be valid, got %v", f.Errors)
+	}
+	if f.FileDigests["upload"] != "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824" {
+		t.Errorf("Expected the SHA-256 digest to be stored, got %q", f.FileDigests["upload"])
+	}
+	var _, file = f.Field("upload").Value().File()
+	if b, _ := io.ReadAll(file); string(b) != "hello" {
+		t.Errorf("Expected the file to be rewound after the hook, got %q", b)
+	}
+
+	f = forms.Form{}
+	f.FileField("upload", "upload", "", "", "")
+	f.FileHook = func(field, filename string, r io.ReadSeeker) error {
+		var magic = make([]byte, 2)
+		io.ReadFull(r, magic)
+		if string(magic) == "MZ" {
+			return errors.New("executables are not allowed")
+		}
+		return nil
+	}
+	if f.Fill(&request.Request{Request: newUploadRequest("upload", "MZ\x90\x00")}) {
+		t.Errorf("Expected the rejected file to make the form invalid")
+	}
+	if !f.Field("upload").HasError() {
+		t.Errorf("Expected the hook error to be attached to the field")
+	}
+}
+
+func TestMaxFileSize(t *testing.T) {
+	var f = &forms.Form{}
+	f.AddFileField("avatar", forms.WithMaxFileSize(4))
+	if !f.Fill(&request.Request{Request: newUploadRequest("avatar", "tiny")}) {
+		t.Fatalf("Expected a file within the limit to be accepted, got %v", f.Errors)
+	}
+	if f.Field("avatar").Value().FileName != "file.bin" {
+		t.Errorf("Expected the file to be set on the field")
+	}
+
+	f.Clear()
+	if f.Fill(&request.Request{Request: newUploadRequest("avatar", "too large")}) {
+		t.Errorf("Expected an oversized file to be rejected")
+	}
+	if errs := f.Field("avatar").Errors(); len(errs) != 1 || !errors.Is(errs[0].FieldErr, forms.ErrFileTooLarge) {
+		t.Errorf("Expected ErrFileTooLarge on the field, got %v", errs)
+	}
+	if _, file := f.Field("avatar").GetFile(); file != nil {
+		t.Errorf("Expected the oversized file not to be set on the field")
+	}
+
+	if f.FillMultipartStream(newUploadRequest("avatar", "too large")) {
+		t.Errorf("Expected an oversized streamed file to be rejected")
+	}
+	if errs := f.Field("avatar").Errors(); len(errs) != 1 || !errors.Is(errs[0].FieldErr, forms.ErrFileTooLarge) {
+		t.Errorf("Expected ErrFileTooLarge on the streamed field, got %v", errs)
+	}
+
+	if got := f.Field("avatar").Field().String(); !strings.Contains(got, ` data-max-size="4"`) {
+		t.Errorf("Expected the data-max-size attribute, got %s", got)
+	}
+}
@@ -0,0 +1,59 @@
+package forms
+
+import (
+	"mime"
+	"net/textproto"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// The maximum length in bytes of a sanitized filename.
+var MaxFilenameLength = 255
+
+// SanitizeFilename makes an uploaded filename safe to use as a single path component.
+//
+// The name is NFC normalized, directory components are stripped,
+// control and formatting characters are dropped, and the name is truncated to MaxFilenameLength,
+// keeping the extension where possible. Names which end up empty become "file".
+func SanitizeFilename(name string) string {
+	name = norm.NFC.String(name)
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.Map(func(r rune) rune {
+		if r == utf8.RuneError || unicode.IsControl(r) || unicode.Is(unicode.Cf, r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.Trim(name, " .")
+	if name == "" {
+		return "file"
+	}
+	if len(name) <= MaxFilenameLength {
+		return name
+	}
+
+	var ext string
+	if i := strings.LastIndexByte(name, '.'); i > 0 && len(name)-i < MaxFilenameLength/2 {
+		name, ext = name[:i], name[i:]
+	}
+	var max = MaxFilenameLength - len(ext)
+	for max > 0 && !utf8.RuneStart(name[max]) {
+		max--
+	}
+	return name[:max] + ext
+}
+
+// submittedFilename returns the filename from the Content-Disposition header of a part as the client sent it,
+// mime/multipart already strips directories from the name it reports.
+func submittedFilename(header textproto.MIMEHeader, fallback string) string {
+	var _, params, err = mime.ParseMediaType(header.Get("Content-Disposition"))
+	if err != nil || params["filename"] == "" {
+		return fallback
+	}
+	return params["filename"]
+}
@@ -0,0 +1,57 @@
+package forms_test
+
+import (
+	"io"
+	"mime/multipart"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+	"github.com/Nigel2392/router/v3/request"
+)
+
+func TestSanitizeFilename(t *testing.T) {
+	var tests = []struct {
+		name     string
+		expected string
+	}{
+		{"report.pdf", "report.pdf"},
+		{"../../etc/passwd", "passwd"},
+		{`C:\Users\john\..\secret.txt`, "secret.txt"},
+		{"evil\x00.php\x00.jpg", "evil.php.jpg"},
+		{"line\r\nbreak.txt", "linebreak.txt"},
+		{"invoice\u202egpj.exe", "invoicegpj.exe"},
+		{"cafe\u0301.txt", "caf\u00e9.txt"},
+		{"..", "file"},
+		{"/", "file"},
+		{" .hidden. ", "hidden"},
+		{strings.Repeat("a", 300) + ".txt", strings.Repeat("a", 251) + ".txt"},
+		{strings.Repeat("\u00e9", 200), strings.Repeat("\u00e9", 127)},
+	}
+	for _, test := range tests {
+		if got := forms.SanitizeFilename(test.name); got != test.expected {
+			t.Errorf("SanitizeFilename(%q): expected %q, got %q", test.name, test.expected, got)
+		}
+	}
+
+	var r = newMultipartRequest(func(w *multipart.Writer) {
+		var part, _ = w.CreateFormFile("upload", "../../etc/passwd")
+		part.Write([]byte("root"))
+	})
+
+	var f = forms.Form{}
+	f.AddFileField("upload")
+	var filename string
+	f.FileHook = func(field, name string, r io.ReadSeeker) error {
+		filename = name
+		return nil
+	}
+	f.Fill(&request.Request{Request: r})
+	var value = f.Field("upload").Value()
+	if value.FileName != "passwd" || filename != "passwd" {
+		t.Errorf("Expected the sanitized name on the field and in the hook, got %q and %q", value.FileName, filename)
+	}
+	if value.OriginalFileName != "../../etc/passwd" {
+		t.Errorf("Expected the original name to be kept, got %q", value.OriginalFileName)
+	}
+}
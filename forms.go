@@ -1,12 +1,20 @@
 package forms
 
 import (
+	"context"
+	"encoding"
 	"errors"
 	"fmt"
 	"html/template"
+	"math"
+	"mime"
+	"net/http"
+	"net/url"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Nigel2392/forms/validators"
 	"github.com/Nigel2392/router/v3/request"
@@ -14,54 +22,657 @@ import (
 	"golang.org/x/text/language"
 )
 
+// ErrFill is wrapped by errors which occur while reading or parsing the submitted data.
+var ErrFill = errors.New("could not read submitted form data")
+
+// ErrNilForm is returned by methods which cannot do their work on a nil *Form, such as Scan.
+//
+// Other methods are safe to call on a nil *Form as well: it behaves like an empty form
+// which can never be filled or validated successfully, and to which fields and errors cannot be added.
+var ErrNilForm = errors.New("form is nil")
+
+// ErrMissingField is added by Validate for every name passed to RequireFields which was never added to the form.
+var ErrMissingField = errors.New("expected field was never added to the form")
+
+// ErrReadOnly is added to a read-only field when the submitted value differs from its initial value.
+var ErrReadOnly = errors.New("cannot be modified")
+
+// The maximum amount of memory used to parse multipart forms, the rest is stored in temporary files.
+var MaxMemory int64 = 32 << 20
+
 func NewValue(s string) *FormData {
 	return &FormData{Val: []string{s}}
 }
 
 type Form struct {
-	Fields      []FormElement
-	Errors      FormErrors
+	// Identifies the form in log events and metrics.
+	Name   string
+	Fields []FormElement
+	Errors FormErrors
+
+	// Receives debug events when a fill starts and a field is invalid, and info events with the outcome of validation.
+	// Nil is silent.
+	Logger EventLogger
+	// Observes the outcome and duration of every fill and validation.
+	Metrics Metrics
+
+	// Called before and after validation during Fill, AfterValid only if the form is valid.
+	//
+	// Deprecated: use BeforeValidate and AfterValidate, which do not depend on the router's request type.
+	// If both are set, BeforeValid runs before BeforeValidate, and AfterValid before AfterValidate.
 	BeforeValid func(*request.Request, *Form) error
 	AfterValid  func(*request.Request, *Form) error
+
+	// Called with the context of the request before and after validation, AfterValidate only if the form is valid.
+	// An error in either hook makes the form invalid, with the error added under "Validation".
+	BeforeValidate func(ctx context.Context, f *Form) error
+	AfterValidate  func(ctx context.Context, f *Form) error
+
+	// Default required indicator for fields which do not set their own.
+	RequiredIndicator template.HTML
+
+	// Line endings and compact output, defaults to DefaultRenderConfig.
+	RenderConfig *RenderConfig
+
+	// Stop validating at the first invalid field, e.g. to skip expensive validators of API endpoints.
+	StopOnFirstError bool
+	// The fields which were not validated because of StopOnFirstError.
+	SkippedFields []string
+
+	// Render the ErrorSummary at the top of AsP.
+	ShowErrorSummary bool
+
+	// Default classes of fields, labels and the paragraphs wrapping them,
+	// used for fields which do not set their own, e.g. when constructed with an empty classes argument.
+	DefaultFieldClass   string
+	DefaultLabelClass   string
+	DefaultWrapperClass string
+
+	// Render the inputs of checkbox and radio fields before their labels, see Field.LabelAfter.
+	LabelAfter bool
+
+	// Default class appended to the class of fields with errors, see Field.ErrorClass.
+	ErrorClass string
+	// Class added to the paragraph wrapping fields with errors, e.g. "has-error".
+	ErrorWrapperClass string
+
+	// Derives label text from field names, defaults to DefaultLabeler.
+	// Only labels which were derived from the name are replaced when a field is added.
+	Labeler func(fieldName string) string
+
+	// Attributes of the form tag rendered by form_open, such as hx-post, see HTMX.
+	HXAttrs map[string]string
+
+	// Formats the ID of fields without an ID from their name, e.g. "id_%s".
+	// By default, the name is used as the ID.
+	AutoIDFormat string
+	// Prepended to the IDs of all fields, e.g. to render a form more than once on a page without duplicate IDs.
+	// Labels use the same ID, see Field.EffectiveID.
+	Prefix string
+
+	// Match field names case sensitively in lookups, Scan and Fill.
+	// By default, names are matched case insensitively.
+	CaseSensitive bool
+
+	// Validate disabled fields as well, browsers do not submit them,
+	// so by default they are skipped during validation.
+	ValidateDisabled bool
+
+	// Set when the submitted data could not be read or parsed during Fill.
+	// It wraps ErrFill, allowing handlers to distinguish it from validation errors,
+	// or ErrCSRF when the CSRF token could not be verified,
+	// or ErrReplayed when the idempotency token was already consumed.
+	FillError error
+
+	// Renders the errors of the fields, unless a field has its own ErrorRenderer. Defaults to ListErrors.
+	ErrorRenderer ErrorRenderer
+
+	// Translates the messages of validation errors from their code and params during validation.
+	Translator Translator
+
+	// Returns the client IP passed to captcha verification and RequestInfo during Fill, defaults to RemoteIP.
+	ClientIP func(r *http.Request) string
+
+	// Called for every uploaded file during Fill and FillMultipartStream.
+	FileHook FileHook
+	// The digests stored by the SHA256Digest file hook, keyed by field name.
+	FileDigests map[string]string
+
+	// The context passed to ContextValidators while validating.
+	ctx context.Context
+
+	// Receives uploaded files during FillMultipartStream, defaults to MemorySink.
+	FileSink FileSink
+	// The maximum size of a non-file part during FillMultipartStream, defaults to DefaultMaxValueSize.
+	MaxValueSize int64
+	// The maximum size and number of all non-file parts during FillMultipartStream,
+	// defaulting to DefaultMaxValuesSize and DefaultMaxValueParts.
+	MaxValuesSize int64
+	MaxValueParts int
+
+	// Groups of fields rendered inside of a <fieldset>.
+	FieldSets []*FieldSet
+
+	// The step of a multi-step form, filled from the StepFieldName field during Fill.
+	CurrentStep int
+	steps       [][]string
+	csrfToken   string
+	csrfExempt  bool
+	tokenStore  TokenStore
+	// The hidden field added by VersionField, and the version it must hold.
+	versionField    *Field
+	expectedVersion string
+	// Set when an uploaded file was rejected during fill, until the form is validated.
+	fileRejected bool
+	// The values of read-only fields before they were first filled, keyed by nameKey.
+	readOnly map[string][]string
+	// The names passed to RequireFields.
+	expectedFields []string
 }
 
+// nameMatches reports whether a field name matches the requested name.
+func (f *Form) nameMatches(fieldName, name string) bool {
+	if f == nil {
+		return false
+	}
+	if f.CaseSensitive {
+		return fieldName == name
+	}
+	return strings.EqualFold(fieldName, name)
+}
+
+// nameKey returns the key used to compare field names in maps.
+func (f *Form) nameKey(name string) string {
+	if f == nil {
+		return name
+	}
+	if f.CaseSensitive {
+		return name
+	}
+	return strings.ToLower(name)
+}
+
+// lookup returns the submitted data for a field name, preferring an exact match.
+func lookup[T any](m map[string][]T, name string, caseSensitive bool) []T {
+	if v, ok := m[name]; ok || caseSensitive {
+		return v
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return nil
+}
+
+// formBound is implemented by fields which need access to their form for form-level defaults.
+type formBound interface {
+	setForm(*Form) (previous *Form)
+}
+
+// bind makes sure every field knows which form it belongs to.
+func (f *Form) bind() {
+	for _, field := range f.Fields {
+		if b, ok := field.(formBound); ok {
+			b.setForm(f)
+		}
+	}
+}
+
+// bindCopy binds the fields to f like bind, returning a function which binds them to their previous forms again,
+// so methods with a value receiver do not leave the fields bound to their copy of the form.
+func (f *Form) bindCopy() (restore func()) {
+	var previous = make([]*Form, len(f.Fields))
+	for i, field := range f.Fields {
+		if b, ok := field.(formBound); ok {
+			previous[i] = b.setForm(f)
+		}
+	}
+	return func() {
+		for i, field := range f.Fields {
+			if b, ok := field.(formBound); ok {
+				b.setForm(previous[i])
+			}
+		}
+	}
+}
+
+// Validate all fields of the form, in the order they were added.
+//
+// Fields whose dependency (see Field.DependsOn) is not met are skipped,
+// as are disabled fields unless ValidateDisabled is set.
+// Read-only fields are only checked to still hold the value they had before the form was filled,
+// and the submitted version of a VersionField is checked against the expected version.
+// If StopOnFirstError is set, validation stops at the first invalid field
+// and the names of the fields which were not validated are stored in SkippedFields.
+//
+// The previous errors of the fields are replaced, e.g. those of a preceding Fill.
 func (f *Form) Validate() bool {
+	if f == nil {
+		return false
+	}
+	var start = time.Now()
+	f.resetErrors(f.Fields)
+	var valid = f.validateFields(f.Fields)
+	f.observe(start, valid)
+	return valid
+}
+
+// ValidateCtx is Validate, passing ctx to the ContextValidators of the fields.
+func (f *Form) ValidateCtx(ctx context.Context) bool {
+	if f == nil {
+		return false
+	}
+	f.ctx = ctx
+	defer func() { f.ctx = nil }()
+	return f.Validate()
+}
+
+// ValidateOnly validates only the named fields, with their FieldValidators and ContextValidators,
+// along with the fields which depend on them (see Field.DependsOn) or compare themselves to them
+// (see FieldReferencer), e.g. for live inline validation.
+// Unlike Fill, it validates the values the fields already hold, e.g. after FillValues.
+//
+// The previous errors of the validated fields are replaced, the errors of other fields are left untouched.
+// Names without a field are reported as an error under that name.
+func (f *Form) ValidateOnly(names ...string) (valid bool) {
+	if f == nil {
+		return false
+	}
+	var start = time.Now()
+	defer func() { f.observe(start, valid) }()
+	valid = true
+	var fields = make([]FormElement, 0, len(names))
+	var selected = make(map[FormElement]bool, len(names))
+	for _, name := range names {
+		var field = f.Field(name)
+		if field == nil {
+			valid = false
+			f.AddError(name, fmt.Errorf("no field named %q", name))
+			continue
+		}
+		if !selected[field] {
+			selected[field] = true
+			fields = append(fields, field)
+		}
+	}
+	for _, field := range f.Fields {
+		var fld, ok = asField(field)
+		if !ok || selected[field] {
+			continue
+		}
+		for _, name := range names {
+			if fld.DependsOn != nil && f.nameMatches(fld.DependsOn.Field, name) || f.referencesField(fld, name) {
+				selected[field] = true
+				fields = append(fields, field)
+				break
+			}
+		}
+	}
+
+	f.resetErrors(fields)
+	var inactive, err = f.inactiveFields()
+	if err != nil {
+		valid = false
+		f.AddError("Dependencies", err)
+	}
+	for _, field := range fields {
+		if inactive[field.GetName()] || f.skipDisabled(field) || isComputed(field) {
+			continue
+		}
+		if err := f.validateField(field); err != nil {
+			valid = false
+			for _, err := range splitErrors(err) {
+				err = f.translate(err)
+				var fe = newFormError(field.GetName(), err)
+				f.Errors = append(f.Errors, fe)
+				f.fieldInvalid(fe)
+				field.AddError(err)
+			}
+		}
+	}
+	return valid
+}
+
+// validateFields validates the given subset of the form's fields.
+func (f *Form) validateFields(fields []FormElement) bool {
 	var valid = true
 	if f.Errors == nil {
 		f.Errors = make(FormErrors, 0)
 	}
-	for _, field := range f.Fields {
-		var err = field.Validate()
+	var inactive, err = f.inactiveFields()
+	if err != nil {
+		valid = false
+		f.AddError("Dependencies", err)
+	}
+	f.SkippedFields = nil
+	if err := f.checkVersion(); err != nil {
+		valid = false
+		f.AddError(VersionFieldName, err)
+	}
+	for _, name := range f.expectedFields {
+		if !f.HasField(name) {
+			valid = false
+			f.AddError(name, ErrMissingField)
+		}
+	}
+	for i, field := range fields {
+		if inactive[field.GetName()] || f.skipDisabled(field) || isComputed(field) {
+			continue
+		}
+		var err = f.validateField(field)
 		if err != nil {
 			valid = false
-			f.Errors = append(f.Errors, FormError{
-				Name:     field.GetName(),
-				FieldErr: err,
-			})
-			field.AddError(err)
+			for _, err := range splitErrors(err) {
+				err = f.translate(err)
+				var fe = newFormError(field.GetName(), err)
+				f.Errors = append(f.Errors, fe)
+				f.fieldInvalid(fe)
+				field.AddError(err)
+			}
+			if f.StopOnFirstError {
+				f.skip(fields[i+1:], inactive)
+				break
+			}
 		}
 	}
 	return valid
 }
 
+// isComputed reports whether the value of the field is computed, see Field.Computed.
+func isComputed(field FormElement) bool {
+	var fld, ok = asField(field)
+	return ok && fld.Computed != nil
+}
+
+// skipDisabled reports whether the field is disabled and should not be validated.
+func (f *Form) skipDisabled(field FormElement) bool {
+	var fld, ok = asField(field)
+	return ok && fld.Disabled && !f.ValidateDisabled
+}
+
+// validateField validates a single field, followed by its FieldValidators and ContextValidators.
+//
+// Read-only fields are not validated, they are only checked against their initial value to reject tampering.
+func (f *Form) validateField(field FormElement) error {
+	var fld, ok = asField(field)
+	if ok && fld.ReadOnly {
+		var initial, filled = f.readOnly[f.nameKey(fld.Name)]
+		if filled && !equalValues(initial, fld.GetValue()) {
+			return ErrReadOnly
+		}
+		return nil
+	}
+	var err = field.Validate()
+	if !ok || err != nil && (fld.StopOnFirstFieldError || errors.Is(err, ErrRequired)) {
+		return err
+	}
+	var errs = []error{err}
+	for _, validator := range fld.FieldValidators {
+		if err := validator.Validate(f, field); err != nil {
+			if fld.StopOnFirstFieldError {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+	var ctx = f.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for _, validator := range fld.ContextValidators {
+		if err := validator(ctx, field); err != nil {
+			if fld.StopOnFirstFieldError {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// splitErrors flattens errors joined with errors.Join, so each of them is reported separately.
+func splitErrors(err error) []error {
+	var joined, ok = err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+	var errs []error
+	for _, err := range joined.Unwrap() {
+		errs = append(errs, splitErrors(err)...)
+	}
+	return errs
+}
+
+// composite is implemented by fields rendered as multiple inputs, which collect their value from all of them.
+type composite interface {
+	collect(values url.Values, caseSensitive bool) []string
+}
+
+// submitted returns the submitted values of a field.
+func (f *Form) submitted(field FormElement, values url.Values) []string {
+	if c, ok := field.(composite); ok {
+		return c.collect(values, f.CaseSensitive)
+	}
+	return lookup(values, field.GetName(), f.CaseSensitive)
+}
+
+// fill sets the submitted values on a field,
+// remembering the initial value of read-only fields the first time they are filled.
+//
+// Submitted values of computed fields are ignored.
+func (f *Form) fill(field FormElement, values []string) {
+	if isComputed(field) {
+		return
+	}
+	if fld, ok := asField(field); ok && fld.ReadOnly {
+		var key = f.nameKey(fld.Name)
+		if _, ok := f.readOnly[key]; !ok {
+			if f.readOnly == nil {
+				f.readOnly = make(map[string][]string)
+			}
+			f.readOnly[key] = append([]string(nil), fld.GetValue()...)
+		}
+	}
+	field.SetValue(values)
+}
+
+func equalValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// skip records the active fields in SkippedFields.
+func (f *Form) skip(fields []FormElement, inactive map[string]bool) {
+	for _, field := range fields {
+		if !inactive[field.GetName()] {
+			f.SkippedFields = append(f.SkippedFields, field.GetName())
+		}
+	}
+}
+
+// AsP renders the form's fields inside of paragraphs, each preceded by its errors.
+//
+// Hidden fields are rendered first, without a wrapper or label.
+// Their errors are rendered before them in a single list, as there is nowhere to show them next to the field.
+// If ShowErrorSummary is set, the ErrorSummary is rendered before all fields.
+//
+// AsP has a value receiver, so it can be called on forms which are not addressable,
+// such as a Form stored in a map passed to a template.
 func (f Form) AsP() template.HTML {
+	defer f.bindCopy()()
 	var b strings.Builder
-	for _, field := range f.Fields {
-		if !field.HasLabel() {
-			b.WriteString(`<p>`)
-			b.WriteString(field.Label().String())
-			b.WriteString("</p>")
+	if f.ShowErrorSummary {
+		f.writeErrorSummary(&b)
+	}
+	writeHiddenFields(&b, f.Fields)
+	for _, set := range f.FieldSets {
+		set.render(&b, &f, writeVisibleP)
+	}
+	for _, field := range f.unsetFields() {
+		writeVisibleP(&b, field)
+	}
+	return template.HTML(b.String())
+}
+
+// ErrorSummary renders a summary of all errors of the form, linking to the fields they belong to.
+//
+// Errors without a field are rendered as plain text, nothing is rendered when the form has no errors.
+func (f *Form) ErrorSummary() template.HTML {
+	if f == nil {
+		return ""
+	}
+	f.bind()
+	var b strings.Builder
+	f.writeErrorSummary(&b)
+	return template.HTML(b.String())
+}
+
+func (f *Form) writeErrorSummary(b *strings.Builder) {
+	if len(f.Errors) == 0 {
+		return
+	}
+	b.WriteString(`<div class="form-errors" role="alert"><ul>`)
+	for _, err := range f.Errors {
+		b.WriteString(`<li>`)
+		var fld, ok = asField(f.Field(err.Name))
+		if !ok {
+			var msg = err.Error()
+			if err.Name == "" {
+				msg = err.FieldErr.Error()
+			}
+			b.WriteString(template.HTMLEscapeString(msg))
+			b.WriteString(`</li>`)
+			continue
 		}
-		b.WriteString(`<p>`)
-		b.WriteString(field.Field().String())
-		b.WriteString("</p>")
+		var label = fld.LabelText
+		if label == "" {
+			label = fld.Name
+		}
+		b.WriteString(`<a href="#`)
+		b.WriteString(template.HTMLEscapeString(fld.EffectiveID()))
+		b.WriteString(`">`)
+		b.WriteString(template.HTMLEscapeString(label))
+		b.WriteString(`: `)
+		b.WriteString(template.HTMLEscapeString(err.FieldErr.Error()))
+		b.WriteString(`</a></li>`)
+	}
+	b.WriteString(`</ul></div>`)
+	b.WriteString(f.renderConfig().newline())
+}
+
+// RenderHidden renders all hidden fields of the form,
+// for templates which lay out the visible fields themselves.
+func (f *Form) RenderHidden() template.HTML {
+	if f == nil {
+		return ""
 	}
+	f.bind()
+	var b strings.Builder
+	writeHiddenFields(&b, f.Fields)
 	return template.HTML(b.String())
 }
 
-func (f *Form) Fill(r *request.Request) bool {
+// writeHiddenFields writes the errors of the hidden fields among fields in a single list, followed by the hidden fields.
+func writeHiddenFields(b *strings.Builder, fields []FormElement) {
+	var hidden []FormElement
+	for _, field := range fields {
+		if isHidden(field) {
+			hidden = append(hidden, field)
+		}
+	}
+	writeHiddenErrors(b, hidden)
+	for _, field := range hidden {
+		b.WriteString(field.Field().String())
+	}
+}
+
+// writeVisibleP writes the field like writeP, skipping hidden fields.
+func writeVisibleP(b *strings.Builder, field FormElement) {
+	if !isHidden(field) {
+		writeP(b, field)
+	}
+}
+
+// writeP writes the errors of the field, followed by its label and field inside of a single paragraph.
+//
+// Hidden fields are written without a wrapper or label, their errors are written like writeHiddenErrors.
+func writeP(b *strings.Builder, field FormElement) {
+	if isHidden(field) {
+		writeHiddenErrors(b, []FormElement{field})
+		b.WriteString(field.Field().String())
+		return
+	}
+	b.WriteString(string(fieldErrors(field)))
+	var class string
+	if w, ok := field.(errorWrapper); ok {
+		class = w.wrapperClass()
+	}
+	b.WriteString(`<p`)
+	if class != "" {
+		writeAttr(b, "class", class)
+	}
+	b.WriteString(`>`)
+	var after = false
+	if l, ok := field.(labelPlacer); ok {
+		after = l.labelAfter()
+	}
+	if field.HasLabel() && !after {
+		b.WriteString(field.Label().String())
+	}
+	b.WriteString(field.Field().String())
+	if field.HasLabel() && after {
+		b.WriteString(field.Label().String())
+	}
+	b.WriteString("</p>")
+}
+
+type errorWrapper interface {
+	wrapperClass() string
+}
+
+type labelPlacer interface {
+	labelAfter() bool
+}
+
+type hider interface {
+	IsHidden() bool
+}
+
+func isHidden(field FormElement) bool {
+	var h, ok = field.(hider)
+	return ok && h.IsHidden()
+}
+
+// Fill the form with the data from the request, and validate it.
+//
+// If the request body could not be read or parsed, FillError is set
+// and Fill returns false without validating the form.
+func (f *Form) Fill(r *request.Request) (valid bool) {
+	if f == nil {
+		return false
+	}
+	var start = f.fillStarted()
+	defer func() { f.observe(start, valid) }()
 	var err error
-	r.Request.ParseForm()
+	f.resetFill()
+	if err = parseRequest(r.Request); err != nil {
+		f.FillError = fmt.Errorf("%w: %w", ErrFill, err)
+		f.AddError("Form", f.FillError)
+		return false
+	}
+	if err = f.verifyCSRF(r.Request, r.Request.PostForm); err != nil {
+		f.FillError = err
+		f.AddError(CSRFFieldName, err)
+		return false
+	}
 
 	switch r.Method() {
 	case "GET", "HEAD", "DELETE":
@@ -70,6 +681,66 @@ func (f *Form) Fill(r *request.Request) bool {
 		f.fillForm(r)
 	}
 
+	if f.FillError != nil {
+		return false
+	}
+
+	return f.afterFill(r.Request.Context(), r, r.Request.Form.Get(StepFieldName))
+}
+
+// resetFill clears the outcome of a previous fill, so a form filled again only reports the errors of the new submission.
+func (f *Form) resetFill() {
+	f.FillError = nil
+	f.Errors = nil
+	f.SkippedFields = nil
+	for _, field := range f.Fields {
+		if fld, ok := asField(field); ok {
+			fld.FormErrors = nil
+		}
+	}
+}
+
+// resetErrors removes the errors of the given fields from the form and from the fields themselves,
+// so validating them again does not report their errors twice.
+//
+// Errors of the CSRF and idempotency tokens are kept, validation does not check them again.
+func (f *Form) resetErrors(fields []FormElement) {
+	var reset = make(map[FormElement]bool, len(fields))
+	for _, field := range fields {
+		reset[field] = true
+		if fld, ok := asField(field); ok {
+			fld.FormErrors = nil
+		}
+	}
+	var errs = make(FormErrors, 0, len(f.Errors))
+	for _, err := range f.Errors {
+		if err.Name == CSRFFieldName || err.Name == IdempotencyFieldName {
+			errs = append(errs, err)
+			continue
+		}
+		if field := f.Field(err.Name); field == nil || !reset[field] {
+			errs = append(errs, err)
+		}
+	}
+	f.Errors = errs
+}
+
+// afterFill runs the hooks and validation once the form's values are set.
+//
+// The hooks run in the order BeforeValid, BeforeValidate, validation, AfterValid, AfterValidate.
+func (f *Form) afterFill(ctx context.Context, r *request.Request, step string) bool {
+	var err error
+	if len(f.steps) > 0 {
+		f.CurrentStep = f.stepFromRequest(step)
+	}
+
+	if r != nil && r.Request != nil {
+		f.bindRequest(r.Request)
+		ctx = WithRequestInfo(ctx, f.requestInfo(r.Request))
+	}
+
+	var normalized = f.normalize()
+
 	if f.BeforeValid != nil {
 		err = f.BeforeValid(r, f)
 		if err != nil {
@@ -77,13 +748,51 @@ func (f *Form) Fill(r *request.Request) bool {
 			return false
 		}
 	}
+	if f.BeforeValidate != nil {
+		err = f.BeforeValidate(ctx, f)
+		if err != nil {
+			f.AddError("Validation", err)
+			return false
+		}
+	}
+
+	var valid bool
+	f.ctx = ctx
+	if len(f.steps) > 0 {
+		valid = f.validateFields(f.fieldsUpToStep(f.CurrentStep))
+	} else {
+		valid = f.validateFields(f.Fields)
+	}
+	f.ctx = nil
+	valid = valid && normalized && !f.fileRejected
+	f.fileRejected = false
 
-	valid := f.Validate()
+	var consumed = valid && f.IsLastStep() && f.tokenStore != nil
+	if consumed {
+		if err = f.consumeToken(); err != nil {
+			f.FillError = err
+			f.AddError(IdempotencyFieldName, err)
+			return false
+		}
+	}
 
 	if f.AfterValid != nil && valid {
 		err = f.AfterValid(r, f)
 		if err != nil {
 			f.AddError("Validation", err)
+			if consumed {
+				f.releaseToken()
+			}
+			return false
+		}
+	}
+	if f.AfterValidate != nil && valid {
+		err = f.AfterValidate(ctx, f)
+		if err != nil {
+			f.AddError("Validation", err)
+			if consumed {
+				f.releaseToken()
+			}
 			return false
 		}
 	}
@@ -91,12 +800,93 @@ func (f *Form) Fill(r *request.Request) bool {
 	return valid
 }
 
+// parseRequest parses the request body, multipart forms included.
+func parseRequest(r *http.Request) error {
+	var mediaType, _, _ = mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		var err = r.ParseMultipartForm(MaxMemory)
+		if err != nil && !errors.Is(err, http.ErrNotMultipart) {
+			return err
+		}
+		return nil
+	}
+	return r.ParseForm()
+}
+
 func (f *Form) fillQueries(r *request.Request) {
+	var values = r.Request.Form
+	if len(values) == 0 {
+		values = r.Request.URL.Query()
+	}
 	for _, field := range f.Fields {
-		field.SetValue(r.Request.Form[field.GetName()])
+		if field.IsFile() {
+			continue
+		}
+		f.fill(field, f.submitted(field, values))
 	}
 }
 
+// Values returns the current values of all non-file fields, preserving multiple values,
+// e.g. to rebuild a redirect URL or to store a submission in a session.
+//
+// The names of the skipped file fields are returned as well,
+// computed fields and the version field are skipped entirely.
+func (f *Form) Values() (values url.Values, files []string) {
+	if f == nil {
+		return nil, nil
+	}
+	values = make(url.Values, len(f.Fields))
+	for _, field := range f.Fields {
+		if field.IsFile() {
+			files = append(files, field.GetName())
+			continue
+		}
+		if isComputed(field) || f.isVersionField(field) {
+			continue
+		}
+		var v = field.GetValue()
+		if len(v) == 0 {
+			continue
+		}
+		values[field.GetName()] = append(values[field.GetName()], v...)
+	}
+	return values, files
+}
+
+// Encode returns the values of the form in URL encoded form, see Values.
+func (f *Form) Encode() string {
+	if f == nil {
+		return ""
+	}
+	var values, _ = f.Values()
+	return values.Encode()
+}
+
+// AsQueryString returns the non-empty values of the form's fields as a query,
+// e.g. for pagination links which preserve the current filters of a search form.
+//
+// File fields and buttons are skipped.
+func (f *Form) AsQueryString() url.Values {
+	if f == nil {
+		return nil
+	}
+	var q = make(url.Values)
+	for _, field := range f.Fields {
+		if field.IsFile() {
+			continue
+		}
+		if fld, ok := asField(field); ok && fld.isButton() {
+			continue
+		}
+		for _, v := range field.GetValue() {
+			if v != "" {
+				q.Add(field.GetName(), v)
+			}
+		}
+	}
+	return q
+}
+
 func (f *Form) fillForm(r *request.Request) {
 	for _, field := range f.Fields {
 		if field.IsFile() {
@@ -107,31 +897,96 @@ func (f *Form) fillForm(r *request.Request) {
 			if mForm.File == nil {
 				continue
 			}
-			var readerClosers = mForm.File[field.GetName()]
+			var readerClosers = lookup(mForm.File, field.GetName(), f.CaseSensitive)
 			if len(readerClosers) == 0 {
 				continue
 			}
 			var readerCloser = readerClosers[0]
+			if max := maxFileSize(field); max > 0 && readerCloser.Size > max {
+				f.rejectFile(field, fileTooLarge(max))
+				continue
+			}
 			var file, err = readerCloser.Open()
 			if err != nil {
+				err = fmt.Errorf("%w: %w", ErrFill, err)
+				if f.FillError == nil {
+					f.FillError = err
+				}
 				f.AddError(field.GetName(), err)
+				field.AddError(err)
+				continue
 			}
-			field.SetFile(readerCloser.Filename, file)
+			field.SetFile(submittedFilename(readerCloser.Header, readerCloser.Filename), file)
+			f.runFileHook(field, SanitizeFilename(readerCloser.Filename), file)
+			continue
+		}
+		f.fill(field, f.submitted(field, r.Request.PostForm))
+	}
+}
+
+// FillRequest fills the form from a plain *http.Request, e.g. in chi or net/http handlers, see Fill.
+func (f *Form) FillRequest(r *http.Request) bool {
+	if f == nil {
+		return false
+	}
+	return f.Fill(&request.Request{Request: r})
+}
+
+// FillValues fills the form from plain values, and validates it like Fill.
+//
+// File fields are left untouched.
+// BeforeValid and AfterValid are called with a nil request,
+// BeforeValidate and AfterValidate with context.Background().
+func (f *Form) FillValues(v url.Values) bool {
+	if f == nil {
+		return false
+	}
+	return f.FillCtx(context.Background(), v)
+}
+
+// FillCtx is FillValues, passing ctx to BeforeValidate and AfterValidate.
+func (f *Form) FillCtx(ctx context.Context, v url.Values) (valid bool) {
+	if f == nil {
+		return false
+	}
+	var start = f.fillStarted()
+	defer func() { f.observe(start, valid) }()
+	f.resetFill()
+	for _, field := range f.Fields {
+		if field.IsFile() {
 			continue
 		}
-		field.SetValue(r.Request.PostForm[field.GetName()])
+		f.fill(field, f.submitted(field, v))
 	}
+	return f.afterFill(ctx, nil, v.Get(StepFieldName))
+}
+
+// FillMap fills the form from a map of values, see FillValues.
+func (f *Form) FillMap(m map[string][]string) bool {
+	if f == nil {
+		return false
+	}
+	return f.FillValues(url.Values(m))
 }
 
 func (f *Form) Clear() {
+	if f == nil {
+		return
+	}
 	for _, field := range f.Fields {
 		field.Clear()
 	}
 }
 
+// Field returns the field with the given name.
+//
+// If multiple fields share the name, the first one added is returned.
 func (f *Form) Field(name string) FormElement {
+	if f == nil {
+		return nil
+	}
 	for _, field := range f.Fields {
-		if field.GetName() == name {
+		if f.nameMatches(field.GetName(), name) {
 			return field
 		}
 	}
@@ -140,29 +995,124 @@ func (f *Form) Field(name string) FormElement {
 
 // AddField adds a field to the form
 func (f *Form) AddFields(field ...FormElement) {
+	if f == nil {
+		return
+	}
 	if f.Fields == nil {
 		f.Fields = make([]FormElement, 0)
 	}
-	f.Fields = append(f.Fields, field...)
+	for _, fld := range field {
+		if b, ok := fld.(formBound); ok {
+			b.setForm(f)
+		}
+		if fd, ok := asField(fld); ok {
+			f.relabel(fd)
+			// Make sure labels and fields without an ID or name are still paired.
+			if fd.ID == "" && fd.Name == "" {
+				fd.ID = "field_" + strconv.Itoa(len(f.Fields))
+			}
+		}
+		f.Fields = append(f.Fields, fld)
+	}
+}
+
+// AddFieldsStrict adds fields to the form like AddFields,
+// but returns an error without adding any field if a name is already taken.
+func (f *Form) AddFieldsStrict(field ...FormElement) error {
+	if f == nil {
+		return ErrNilForm
+	}
+	var seen = make(map[string]bool, len(field))
+	for _, fld := range field {
+		var name = fld.GetName()
+		if seen[f.nameKey(name)] || f.HasField(name) {
+			return fmt.Errorf("duplicate field name %q", name)
+		}
+		seen[f.nameKey(name)] = true
+	}
+	f.AddFields(field...)
+	return nil
+}
+
+// ModifyField calls fn with the field of the given name,
+// returning an error if the form has no such field, or if it does not embed a Field (see BaseField).
+func (f *Form) ModifyField(name string, fn func(*Field)) error {
+	var field = f.Field(name)
+	if field == nil {
+		return fmt.Errorf("no field named %q", name)
+	}
+	var fld, ok = asField(field)
+	if !ok {
+		return fmt.Errorf("field %q is a %T, which does not embed a Field", name, field)
+	}
+	fn(fld)
+	return nil
+}
+
+// HasField reports whether the form has a field with the given name.
+func (f *Form) HasField(name string) bool {
+	if f == nil {
+		return false
+	}
+	return f.Field(name) != nil
+}
+
+// Empty reports whether the form is nil or has no fields.
+//
+// An empty form is valid, use RequireFields to make Validate fail when expected fields were never added.
+func (f *Form) Empty() bool {
+	return f == nil || len(f.Fields) == 0
+}
+
+// RequireFields records the names of fields the form is expected to have,
+// Validate fails with ErrMissingField for every name which was never added, e.g. after a field was renamed.
+func (f *Form) RequireFields(names ...string) *Form {
+	if f == nil {
+		return nil
+	}
+	f.expectedFields = append(f.expectedFields, names...)
+	return f
+}
+
+// Dedupe removes fields whose name was already used by an earlier field.
+func (f *Form) Dedupe() {
+	if f == nil {
+		return
+	}
+	var seen = make(map[string]bool, len(f.Fields))
+	var fields = make([]FormElement, 0, len(f.Fields))
+	for _, field := range f.Fields {
+		if seen[f.nameKey(field.GetName())] {
+			continue
+		}
+		seen[f.nameKey(field.GetName())] = true
+		fields = append(fields, field)
+	}
+	f.Fields = fields
 }
 
 // AddError adds an error to the form
+//
+// Errors which are not a validators.ValidationError get the code CodeCustom.
 func (f *Form) AddError(name string, err error) {
+	if f == nil {
+		return
+	}
 	if f.Errors == nil {
 		f.Errors = make(FormErrors, 0)
 	}
-	f.Errors = append(f.Errors, FormError{
-		Name:     name,
-		FieldErr: err,
-	})
+	f.Errors = append(f.Errors, newFormError(name, err))
 }
 
 func (f *Form) Without(names ...string) {
+	if f == nil {
+		return
+	}
 	var fields = make([]FormElement, 0)
 	for _, field := range f.Fields {
 		var found = false
 		for _, name := range names {
-			if strings.EqualFold(field.GetName(), name) {
+			if f.nameMatches(field.GetName(), name) {
 				found = true
 				break
 			}
@@ -174,7 +1124,27 @@ func (f *Form) Without(names ...string) {
 	f.Fields = fields
 }
 
+// Only keeps the fields with the given names, in their original order.
+func (f *Form) Only(names ...string) {
+	if f == nil {
+		return
+	}
+	var fields = make([]FormElement, 0, len(names))
+	for _, field := range f.Fields {
+		for _, name := range names {
+			if f.nameMatches(field.GetName(), name) {
+				fields = append(fields, field)
+				break
+			}
+		}
+	}
+	f.Fields = fields
+}
+
 func (f *Form) Disabled(names ...string) Form {
+	if f == nil {
+		return Form{}
+	}
 	if len(names) == 0 {
 		for _, field := range f.Fields {
 			field.SetDisabled(true)
@@ -183,7 +1153,7 @@ func (f *Form) Disabled(names ...string) Form {
 	}
 	for _, field := range f.Fields {
 		for _, name := range names {
-			if strings.EqualFold(field.GetName(), name) {
+			if f.nameMatches(field.GetName(), name) {
 				field.SetDisabled(true)
 				break
 			}
@@ -192,9 +1162,15 @@ func (f *Form) Disabled(names ...string) Form {
 	return *f
 }
 
+// Get returns the value of the field with the given name.
+//
+// If multiple fields share the name, the value of the first one added is returned.
 func (f *Form) Get(name string) *FormData {
+	if f == nil {
+		return nil
+	}
 	for _, field := range f.Fields {
-		if field.GetName() == name {
+		if f.nameMatches(field.GetName(), name) {
 			return field.Value()
 		}
 	}
@@ -203,10 +1179,16 @@ func (f *Form) Get(name string) *FormData {
 
 var DefaultTitleCaser = cases.Title(language.English).String
 
+// CSRFToken adds the hidden csrf_token field.
+//
+// The token is verified during Fill, see verifyCSRF.
 func (f *Form) CSRFToken(csrf_token string) *Form {
-	var field = newField(TypeHidden, "csrf_token", "csrf_token", "", "", csrf_token)
-	field.LabelText = ""
+	if f == nil {
+		return nil
+	}
+	var field = newField(TypeHidden, CSRFFieldName, CSRFFieldName, "", "", csrf_token, WithLabel(""), WithAutocomplete(AutocompleteOff), WithSensitive())
 	f.AddFields(field)
+	f.csrfToken = csrf_token
 	return f
 }
 
@@ -216,17 +1198,31 @@ func (f *Form) TextField(name string, id string, classes string, placeholder str
 	return field
 }
 
+// PasswordField adds a password field, autocompleting the current password.
+//
+// Set Autocomplete to AutocompleteNewPassword for sign-up and password change forms.
 func (f *Form) PasswordField(name string, id string, classes string, placeholder string, value string) *Field {
-	var field = newField(TypePassword, name, id, classes, placeholder, value)
+	var field = newField(TypePassword, name, id, classes, placeholder, value, WithAutocomplete(AutocompleteCurrentPassword))
 	f.AddFields(field)
 	return field
 }
 
 func (f *Form) EmailField(name string, id string, classes string, placeholder string, value string) *Field {
-	var field = newField(TypeEmail, name, id, classes, placeholder, value)
-	field.Validators = validators.New(
-		validators.Email,
-	)
+	var field = newField(TypeEmail, name, id, classes, placeholder, value, WithValidators(validators.Email))
+	f.AddFields(field)
+	return field
+}
+
+// ColorField adds a color input, validating the value is a #rrggbb color.
+func (f *Form) ColorField(name string, id string, classes string, value string) *Field {
+	var field = newField(TypeColor, name, id, classes, "", value, WithValidators(validators.HexColor(false)))
+	f.AddFields(field)
+	return field
+}
+
+// URLField adds a url input, validating the value is an absolute http or https URL.
+func (f *Form) URLField(name string, id string, classes string, placeholder string, value string) *Field {
+	var field = newField(TypeURL, name, id, classes, placeholder, value, WithValidators(validators.URL))
 	f.AddFields(field)
 	return field
 }
@@ -239,8 +1235,7 @@ func (f *Form) NumberField(name string, id string, classes string, placeholder s
 }
 
 func (f *Form) FileField(name string, id string, classes string, placeholder string, path string) *Field {
-	var field = newField(TypeFile, name, id, classes, placeholder, "")
-	field.LabelText = path
+	var field = newField(TypeFile, name, id, classes, placeholder, "", WithLabel(path))
 	f.AddFields(field)
 	return field
 }
@@ -258,22 +1253,19 @@ func (f *Form) TextAreaField(name string, id string, classes string, placeholder
 }
 
 func (f *Form) SelectField(name string, id string, classes string, options []Option) *Field {
-	var field = newField(TypeSelect, name, id, classes, "", "")
-	field.Options = options
+	var field = newField(TypeSelect, name, id, classes, "", "", WithOptions(options))
 	f.AddFields(field)
 	return field
 }
 
 func (f *Form) CheckboxField(name string, id string, classes string, placeholder string, value bool) *Field {
-	var field = newField(TypeCheck, name, id, classes, placeholder, "")
-	field.SetChecked(value)
+	var field = newField(TypeCheck, name, id, classes, placeholder, "", WithChecked(value))
 	f.AddFields(field)
 	return field
 }
 
 func (f *Form) RadioField(name string, id string, classes string, placeholder string, value bool) *Field {
-	var field = newField(TypeRadio, name, id, classes, placeholder, "")
-	field.Checked = value
+	var field = newField(TypeRadio, name, id, classes, placeholder, "", WithChecked(value))
 	f.AddFields(field)
 	return field
 }
@@ -296,9 +1288,11 @@ func (f *Form) Button(name string, id string, classes string, value string) *Fie
 	return field
 }
 
-// Any field which is not a primitive type or a slice of a primitive type must implement this interface to be scanned
+// Any field which is not a primitive type or a slice of a primitive type must implement this interface,
+// or encoding.TextUnmarshaler, to be scanned
 //
-// The field must be able to scan a string into itself
+// The field must be able to scan a string into itself.
+// Scanner takes precedence over encoding.TextUnmarshaler, which takes precedence over the kind of the field.
 type Scanner interface {
 	ScanStr(string) error
 }
@@ -308,22 +1302,31 @@ type Valuer interface {
 	StringValue() string
 }
 
+// OptionLister is implemented by types with a fixed set of values, such as enums.
+//
+// Struct fields of such a type are generated as a select of the listed options,
+// with the current value selected. Submitted values are restricted to the listed options.
+type OptionLister interface {
+	Choices() []Option
+}
+
 // Scan scans the form data into the form fields
 //
 // Otherwise, the fields are scanned in the order they are provided.
 //
-// # The fields are matched by it's GetName() method, case insensitive
+// # The fields are matched by it's GetName() method, case insensitive unless Form.CaseSensitive is set
 //
 // If fields is ["*"] or len(fields) == 0, all fields are scanned
 func (f *Form) Scan(fields []string, data ...any) error {
-	var isAllFields = false
+	if f == nil {
+		return ErrNilForm
+	}
+	var isAllFields = len(fields) == 0 || len(fields) == 1 && fields[0] == "*"
 	if len(fields) != len(data) {
 		if len(fields) >= 1 && fields[0] == "*" {
 			isAllFields = true
-		} else if len(fields) == 0 {
-			isAllFields = true
-		} else {
-			return fmt.Errorf("fields and data must be of same length, otherwise fields must be '*' or empty")
+		} else if !isAllFields {
+			return fmt.Errorf("got %d field names but %d destinations, the lengths must match unless fields is '*' or empty", len(fields), len(data))
 		}
 	}
 	var fieldsInOrder []FormElement
@@ -331,140 +1334,316 @@ func (f *Form) Scan(fields []string, data ...any) error {
 		fieldsInOrder = f.Fields
 	} else {
 		fieldsInOrder = make([]FormElement, 0, len(fields))
+		var unmatched []string
 		for _, field := range fields {
+			var found = false
 		inner:
-			for _, f := range f.Fields {
-				if strings.EqualFold(f.GetName(), field) {
-					fieldsInOrder = append(fieldsInOrder, f)
+			for _, fld := range f.Fields {
+				if f.nameMatches(fld.GetName(), field) {
+					fieldsInOrder = append(fieldsInOrder, fld)
+					found = true
 					break inner
 				}
 			}
+			if !found {
+				unmatched = append(unmatched, "'"+field+"'")
+			}
+		}
+		if len(unmatched) > 0 {
+			return fmt.Errorf("no field named %s", strings.Join(unmatched, ", "))
 		}
 	}
 
 	// Verify that the data and fields lengths are the same again.
 	if len(fieldsInOrder) != len(data) {
-		return fmt.Errorf("Length mismatch between fields and data")
+		return fmt.Errorf("the form has %d fields but %d destinations were passed", len(fieldsInOrder), len(data))
 	}
 
 	for i, field := range fieldsInOrder {
+		var scanInto = data[i]
+		if isComputed(field) || f.isVersionField(field) {
+			continue
+		}
+		if fld, ok := asField(field); ok && fld.isCheckable() {
+			// Browsers omit unchecked boxes, scan the checked state instead of the value.
+			if rv := reflect.ValueOf(scanInto); rv.Kind() == reflect.Ptr && rv.Elem().Kind() == reflect.Bool {
+				rv.Elem().SetBool(fld.isChecked())
+				continue
+			}
+		}
+		// Fields without a value, never filled or cleared, leave the destination untouched.
 		var v = field.Value()
-		if v == nil {
+		if v == nil || len(v.Val) == 0 {
 			continue
 		}
-		var scanInto = data[i]
 		var reflectOf = reflect.ValueOf(scanInto)
 		if reflectOf.Kind() != reflect.Ptr {
 			return fmt.Errorf("data must be a pointer")
 		}
-		var fieldVal = field.Value().Value()
-		var fieldValStr string
-		if len(fieldVal) == 0 {
+		var fieldVal = v.Val
+		var fieldValStr = fieldVal[0]
+		var reflectElem = reflectOf.Elem()
+		// Prefer the value parsed during validation over parsing it again, see FormData.Typed.
+		if fld, ok := asField(field); v.Typed != nil && !(ok && fld.MinorUnits) && scanTyped(reflectElem, v.Typed) {
 			continue
 		}
-		fieldValStr = fieldVal[0]
-		var reflectElem = reflectOf.Elem()
-		switch reflectElem.Kind() {
-		case reflect.String:
-			reflectElem.SetString(fieldValStr)
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			var val, err = strconv.ParseInt(fieldValStr, 10, 64)
-			if err != nil {
-				return errors.New("invalid integer")
-			}
-			reflectElem.SetInt(val)
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			var val, err = strconv.ParseUint(fieldValStr, 10, 64)
-			if err != nil {
-				return errors.New("invalid unsigned integer")
+		switch converter := scanInto.(type) {
+		case Scanner:
+			if err := converter.ScanStr(fieldValStr); err != nil {
+				return fmt.Errorf("invalid value, %s", err.Error())
 			}
-			reflectElem.SetUint(val)
-		case reflect.Float32, reflect.Float64:
-			var val, err = strconv.ParseFloat(fieldValStr, 64)
-			if err != nil {
-				return errors.New("invalid float")
+			continue
+		case encoding.TextUnmarshaler:
+			if err := converter.UnmarshalText([]byte(fieldValStr)); err != nil {
+				return fmt.Errorf("invalid value, %s", err.Error())
 			}
-			reflectElem.SetFloat(val)
-		case reflect.Bool:
-			var val, err = parseBool(fieldValStr)
+			continue
+		}
+		if d, ok := scanInto.(*time.Duration); ok {
+			var val, err = scanDuration(field, fieldValStr)
 			if err != nil {
-				return errors.New("invalid boolean")
+				return err
 			}
-			reflectElem.SetBool(val)
-		case reflect.Slice:
-			var elemTyp = reflectElem.Type().Elem()
-			switch elemTyp.Kind() {
-			case reflect.String:
-				reflectElem.Set(reflect.ValueOf(fieldVal))
+			*d = val
+			continue
+		}
+		if fld, ok := asField(field); ok && fld.MinorUnits {
+			switch reflectElem.Kind() {
 			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				var val = make([]int64, 0, len(fieldVal))
-				for _, v := range fieldVal {
-					var i, err = strconv.ParseInt(v, 10, 64)
-					if err != nil {
-						return errors.New("invalid integer")
-					}
-					val = append(val, i)
-				}
-				reflectElem.Set(reflect.ValueOf(val))
-			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				var val = make([]uint64, 0, len(fieldVal))
-				for _, v := range fieldVal {
-					var i, err = strconv.ParseUint(v, 10, 64)
-					if err != nil {
-						return errors.New("invalid unsigned integer")
-					}
-					val = append(val, i)
+				var val, err = toMinorUnits(fieldValStr, fld.DecimalPlaces)
+				if err != nil {
+					return err
 				}
-				reflectElem.Set(reflect.ValueOf(val))
-			case reflect.Float32, reflect.Float64:
-				var val = make([]float64, 0, len(fieldVal))
-				for _, v := range fieldVal {
-					var i, err = strconv.ParseFloat(v, 64)
-					if err != nil {
-						return errors.New("invalid float")
-					}
-					val = append(val, i)
+				reflectElem.SetInt(val)
+				continue
+			}
+		}
+		switch reflectElem.Kind() {
+		case reflect.Slice:
+			var val = reflect.MakeSlice(reflectElem.Type(), len(fieldVal), len(fieldVal))
+			for i, v := range fieldVal {
+				if err := scanValue(val.Index(i), v); err != nil {
+					return err
 				}
-				reflectElem.Set(reflect.ValueOf(val))
-			case reflect.Bool:
-				var val = make([]bool, 0, len(fieldVal))
-				for _, v := range fieldVal {
-					var i, err = parseBool(v)
-					if err != nil {
-						return errors.New("invalid boolean")
-					}
-					val = append(val, i)
+			}
+			reflectElem.Set(val)
+		case reflect.Array:
+			if len(fieldVal) > reflectElem.Len() {
+				return fmt.Errorf("got %d values for %s, an array of length %d", len(fieldVal), field.GetName(), reflectElem.Len())
+			}
+			var val = reflect.New(reflectElem.Type()).Elem()
+			for i, v := range fieldVal {
+				if err := scanValue(val.Index(i), v); err != nil {
+					return err
 				}
-				reflectElem.Set(reflect.ValueOf(val))
-			default:
-				return fmt.Errorf("invalid slice type type, %s", reflectElem.Kind().String())
 			}
+			reflectElem.Set(val)
 		default:
-			var vInterface = reflectOf.Interface()
-			var converter, ok = vInterface.(Scanner)
-			if !ok {
-				return fmt.Errorf("invalid field type, %s", reflectElem.Kind().String())
+			if err := scanValue(reflectElem, fieldValStr); err != nil {
+				return err
 			}
-			var err = converter.ScanStr(fieldValStr)
-			if err != nil {
+		}
+	}
+	return nil
+}
+
+// Integers below maxExactFloat are exactly representable as a float64, larger ones may have been rounded.
+const maxExactFloat = 1 << 53
+
+// scanTyped sets the destination to a value parsed during validation,
+// reporting false if the value cannot be assigned without loss, in which case the string form is parsed instead.
+//
+// Numbers are only converted into destinations which do not parse themselves, see Scanner.
+func scanTyped(dst reflect.Value, typed any) bool {
+	var v = reflect.ValueOf(typed)
+	if v.Type() == dst.Type() {
+		dst.Set(v)
+		return true
+	}
+	if v.Kind() != reflect.Float64 {
+		return false
+	}
+	switch dst.Addr().Interface().(type) {
+	case Scanner, encoding.TextUnmarshaler:
+		return false
+	}
+	var n = v.Float()
+	switch dst.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if dst.OverflowFloat(n) {
+			return false
+		}
+		dst.SetFloat(n)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n != math.Trunc(n) || math.Abs(n) >= maxExactFloat || dst.OverflowInt(int64(n)) {
+			return false
+		}
+		dst.SetInt(int64(n))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n != math.Trunc(n) || n < 0 || n >= maxExactFloat || dst.OverflowUint(uint64(n)) {
+			return false
+		}
+		dst.SetUint(uint64(n))
+	default:
+		return false
+	}
+	return true
+}
+
+// scanValue parses s into the settable value, which may be of a named type such as UserID,
+// or implement Scanner or encoding.TextUnmarshaler through its address.
+func scanValue(dst reflect.Value, s string) error {
+	if dst.CanAddr() {
+		switch converter := dst.Addr().Interface().(type) {
+		case Scanner:
+			if err := converter.ScanStr(s); err != nil {
+				return fmt.Errorf("invalid value, %s", err.Error())
+			}
+			return nil
+		case encoding.TextUnmarshaler:
+			if err := converter.UnmarshalText([]byte(s)); err != nil {
 				return fmt.Errorf("invalid value, %s", err.Error())
 			}
+			return nil
 		}
 	}
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var val, err = strconv.ParseInt(s, 10, dst.Type().Bits())
+		if err != nil {
+			return errors.New("invalid integer")
+		}
+		dst.SetInt(val)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var val, err = strconv.ParseUint(s, 10, dst.Type().Bits())
+		if err != nil {
+			return errors.New("invalid unsigned integer")
+		}
+		dst.SetUint(val)
+	case reflect.Float32, reflect.Float64:
+		var val, err = strconv.ParseFloat(s, dst.Type().Bits())
+		if err != nil {
+			return errors.New("invalid float")
+		}
+		dst.SetFloat(val)
+	case reflect.Bool:
+		var val, err = parseBool(s)
+		if err != nil {
+			return errors.New("invalid boolean")
+		}
+		dst.SetBool(val)
+	default:
+		return fmt.Errorf("invalid field type, %s", dst.Type().String())
+	}
 	return nil
 }
 
-func newField(typ string, name string, id string, classes string, placeholder string, value string) *Field {
-	var field = &Field{
-		Type:        typ,
-		LabelText:   DefaultTitleCaser(name),
-		Name:        name,
-		ID:          id,
-		Class:       classes,
-		Placeholder: placeholder,
-		FormValue:   NewValue(value),
+// ScanMap scans the form data like Scan, with the destination pointers keyed by field name,
+// so they cannot get out of order with the names.
+func (f *Form) ScanMap(data map[string]any) error {
+	if f == nil {
+		return ErrNilForm
 	}
-	return field
+	var names = make([]string, 0, len(data))
+	for name := range data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var dst = make([]any, len(names))
+	for i, name := range names {
+		dst[i] = data[name]
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return f.Scan(names, dst...)
+}
+
+type scanConfig struct {
+	allowDisabled bool
+	structOpts    []StructOption
+}
+
+// A ScanOption configures ScanStruct.
+type ScanOption func(*scanConfig)
+
+// AllowDisabled makes ScanStruct write the values of disabled and readonly fields.
+func AllowDisabled() ScanOption {
+	return func(c *scanConfig) {
+		c.allowDisabled = true
+	}
+}
+
+// WithStructOptions makes ScanStruct match struct fields to form fields the way
+// GenerateFieldsFromStruct does with the same options, e.g. by their json tag with WithTagFallback("json").
+func WithStructOptions(opts ...StructOption) ScanOption {
+	return func(c *scanConfig) {
+		c.structOpts = append(c.structOpts, opts...)
+	}
+}
+
+type lockable interface {
+	IsDisabled() bool
+	IsReadOnly() bool
+}
+
+// ScanStruct scans the form data into the tagged fields of the struct pointed to by dst.
+//
+// Struct fields are matched to form fields by their Go name, or the name given by WithStructOptions,
+// fields missing from the form are left untouched.
+// Disabled and readonly fields are never written, so tampered submissions cannot set them,
+// unless the AllowDisabled option is passed.
+func (f *Form) ScanStruct(dst any, opts ...ScanOption) error {
+	if f == nil {
+		return ErrNilForm
+	}
+	var cfg scanConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	var value = reflect.ValueOf(dst)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return errors.New("dst must be a pointer to a struct")
+	}
+	value = value.Elem()
+	var blueprints, err = structBlueprints(value.Type(), newStructConfig(cfg.structOpts))
+	if err != nil {
+		return err
+	}
+	var names = make([]string, 0, len(blueprints))
+	var data = make([]any, 0, len(blueprints))
+	for _, bp := range blueprints {
+		var field = f.Field(bp.field.Name)
+		if field == nil {
+			continue
+		}
+		if l, ok := field.(lockable); ok && !cfg.allowDisabled && (l.IsDisabled() || l.IsReadOnly()) {
+			continue
+		}
+		var structField = value.Field(bp.index)
+		if !structField.CanAddr() || !structField.CanInterface() {
+			continue
+		}
+		names = append(names, bp.field.Name)
+		data = append(data, structField.Addr().Interface())
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return f.Scan(names, data...)
+}
+
+func newField(typ string, name string, id string, classes string, placeholder string, value string, opts ...FieldOption) *Field {
+	var options = []FieldOption{
+		WithType(typ),
+		WithID(id),
+		WithClass(classes),
+		WithPlaceholder(placeholder),
+		WithValue(value),
+	}
+	return New(name, append(options, opts...)...)
 }
 
 func parseBool(s string) (bool, error) {
@@ -1,17 +1,32 @@
 package forms_test
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/Nigel2392/forms"
+	"github.com/Nigel2392/forms/validators"
+	"github.com/Nigel2392/router/v3/request"
 )
 
 type Structie struct {
-	Name  string   `form:"label:Name:Name; placeholder:Name; required:true;"`
-	Names []string `form:"label:Names:Names; placeholder:Names; required:true;"`
-	Age   int      `form:"label:Age:Age; placeholder:Age; required:true;"`
-	Male  bool     `form:"label:Male:Male; placeholder:Male; required:true;"`
-	Cash  float64  `form:"label:Cash:Cash; placeholder:Cash; required:true;"`
+	Name  string   `form:"label:Name; placeholder:Name; required:true;"`
+	Names []string `form:"label:Names; placeholder:Names; required:true;"`
+	Age   int      `form:"label:Age; placeholder:Age; required:true;"`
+	Male  bool     `form:"label:Male; placeholder:Male; required:true;"`
+	Cash  float64  `form:"label:Cash; placeholder:Cash; required:true;"`
 }
 
 func TestFormFromStruct(t *testing.T) {
@@ -30,21 +45,21 @@ func TestFormFromStruct(t *testing.T) {
 	if len(fields) != 5 {
 		panic("Expected 5 fields")
 	}
-	//	if fields[0].String() != "<label for=\"Name\">Name</label>\r\n<input type=\"text\" id=\"Name\" name=\"Name\" placeholder=\"Name\" value=\"John\" required>\r\n" {
-	//		t.Errorf("Expected \n%s\ngot \n%s", "<label for=\"Name\">Name</label>\r\n<input type=\"text\" id=\"Name\" name=\"Name\" placeholder=\"Name\" value=\"John\" required>\r\n", fields[0].String())
-	//	}
-	//	if fields[1].String() != "<label for=\"Names\">Names</label>\r\n<select type=\"select\" id=\"Names\" name=\"Names\" placeholder=\"Names\" required><option value=\"John\">John</option><option value=\"Doe\">Doe</option></select>" {
-	//		t.Errorf("Expected \n%s\ngot \n%s", "<label for=\"Names\">Names</label>\r\n<select type=\"select\" id=\"Names\" name=\"Names\" placeholder=\"Names\" required><option value=\"John\">John</option><option value=\"Doe\">Doe</option></select>", fields[1].String())
-	//	}
-	//	if fields[2].String() != "<label for=\"Age\">Age</label>\r\n<input type=\"number\" id=\"Age\" name=\"Age\" placeholder=\"Age\" value=\"42\" required>\r\n" {
-	//		t.Errorf("Expected \n%s\ngot \n%s", "<label for=\"Age\">Age</label>\r\n<input type=\"number\" id=\"Age\" name=\"Age\" placeholder=\"Age\" value=\"42\" required>\r\n", fields[2].String())
-	//	}
-	//	if fields[3].String() != "<label for=\"Male\">Male</label>\r\n<input type=\"checkbox\" id=\"Male\" name=\"Male\" placeholder=\"Male\" value=\"true\" required checked>\r\n" {
-	//		t.Errorf("Expected \n%s\ngot \n%s", "<label for=\"Male\">Male</label>\r\n<input type=\"checkbox\" id=\"Male\" name=\"Male\" placeholder=\"Male\" value=\"true\" required checked>\r\n", fields[3].String())
-	//	}
-	//	if fields[4].String() != "<label for=\"Cash\">Cash</label>\r\n<input type=\"number\" id=\"Cash\" name=\"Cash\" placeholder=\"Cash\" value=\"42.420000\" required>\r\n" {
-	//		t.Errorf("Expected \n%s\ngot \n%s", "<label for=\"Cash\">Cash</label>\r\n<input type=\"number\" id=\"Cash\" name=\"Cash\" placeholder=\"Cash\" value=\"42.420000\" required>\r\n", fields[4].String())
-	//	}
+	var expected = []string{
+		"<label for=\"Name\">Name<span class=\"required\">*</span></label>\r\n<input type=\"text\" id=\"Name\" name=\"Name\" value=\"John\" placeholder=\"Name\" required>\r\n",
+		"<label for=\"Names\">Names<span class=\"required\">*</span></label>\r\n<select type=\"select\" id=\"Names\" name=\"Names\" placeholder=\"Names\" required>\r\n<option value=\"John\">John</option>\r\n<option value=\"Doe\">Doe</option>\r\n</select>\r\n",
+		"<label for=\"Age\">Age<span class=\"required\">*</span></label>\r\n<input type=\"number\" id=\"Age\" name=\"Age\" value=\"42\" placeholder=\"Age\" required>\r\n",
+		"<label for=\"Male\">Male<span class=\"required\">*</span></label>\r\n<input type=\"checkbox\" id=\"Male\" name=\"Male\" value=\"on\" checked placeholder=\"Male\" required>\r\n",
+		"<label for=\"Cash\">Cash<span class=\"required\">*</span></label>\r\n<input type=\"number\" id=\"Cash\" name=\"Cash\" value=\"42.42\" placeholder=\"Cash\" required>\r\n",
+	}
+	for i, field := range fields {
+		if field.String() != expected[i] {
+			t.Errorf("Expected \n%s\ngot \n%s", expected[i], field.String())
+		}
+	}
+	if err := fields[4].Validate(); err != nil {
+		t.Errorf("Expected the generated float value to be valid, got %v", err)
+	}
 	for _, field := range fields {
 		if field.String() == "" {
 			t.Errorf("Expected field to be not empty")
@@ -106,3 +121,676 @@ func TestFormScan(t *testing.T) {
 		}
 	}
 }
+
+func newPostRequest(values url.Values) *request.Request {
+	var r = httptest.NewRequest("POST", "/", strings.NewReader(values.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return &request.Request{Request: r}
+}
+
+func TestFieldHooks(t *testing.T) {
+	var f = forms.Form{}
+	var username = f.TextField("username", "username", "", "", " John ")
+	username.PreValidate = func(field *forms.Field) error {
+		field.SetValue([]string{strings.ToLower(strings.TrimSpace(field.Value().String()))})
+		return nil
+	}
+	username.PostValidate = func(field *forms.Field) error {
+		if field.Value().String() == "john" {
+			return errors.New("username is taken")
+		}
+		return nil
+	}
+
+	var clone = username.Clone()
+	if f.Validate() {
+		t.Fatalf("Expected PostValidate to invalidate the form")
+	}
+	if !username.HasError() || username.Errors()[0].FieldErr.Error() != "username is taken" {
+		t.Errorf("Expected the hook error to be attached to the field, got %v", username.Errors())
+	}
+	if clone.PreValidate == nil || clone.Value().String() != " John " || clone.HasError() {
+		t.Errorf("Expected the clone to keep its hooks and be unaffected by validation")
+	}
+}
+
+func TestFillError(t *testing.T) {
+	var f = forms.Form{}
+	f.TextField("name", "name", "", "", "").Required = true
+
+	var r = httptest.NewRequest("POST", "/", strings.NewReader("--broken\r\nContent-Disposition: form-data; name=\"name\"\r\n\r\nJohn"))
+	r.Header.Set("Content-Type", "multipart/form-data; boundary=broken")
+	if f.Fill(&request.Request{Request: r}) {
+		t.Fatalf("Expected a broken multipart body to fail")
+	}
+	if !errors.Is(f.FillError, forms.ErrFill) {
+		t.Errorf("Expected FillError to wrap ErrFill, got %v", f.FillError)
+	}
+	if f.Field("name").HasError() {
+		t.Errorf("Expected fields not to be validated when the body could not be parsed")
+	}
+
+	f = forms.Form{}
+	f.TextField("name", "name", "", "", "")
+	if !f.Fill(newPostRequest(url.Values{"name": {"John"}})) || f.FillError != nil {
+		t.Errorf("Expected a valid body to fill without errors, got %v", f.FillError)
+	}
+}
+
+func TestAsP(t *testing.T) {
+	var f = forms.Form{}
+	f.TextField("name", "name", "", "", "")
+	f.HiddenField("token", "token", "", "", "abc")
+
+	var expected = "<input type=\"hidden\" id=\"token\" name=\"token\" value=\"abc\">\r\n" +
+		"<p><label for=\"name\">Name</label>\r\n<input type=\"text\" id=\"name\" name=\"name\">\r\n</p>"
+	if string(f.AsP()) != expected {
+		t.Errorf("Expected \n%q\ngot \n%q", expected, f.AsP())
+	}
+
+	var tmpl = template.Must(template.New("").Parse(`{{ .Form.AsP }}`))
+	var b strings.Builder
+	if err := tmpl.Execute(&b, map[string]any{"Form": f}); err != nil || b.String() != expected {
+		t.Errorf("Expected a form value to be rendered in a template, got %q (%v)", b.String(), err)
+	}
+	var copied = f
+	copied.DefaultFieldClass = "copied"
+	copied.AsP()
+	f.DefaultFieldClass = "original"
+	if html := f.Field("name").Field().String(); !strings.Contains(html, `class="original"`) {
+		t.Errorf("Expected the fields to stay bound to their form after rendering a copy, got %s", html)
+	}
+}
+
+func TestRenderHidden(t *testing.T) {
+	var f = forms.Form{}
+	f.TextField("name", "name", "", "", "")
+	f.CSRFToken("secret")
+	f.EmailField("email", "email", "", "", "")
+
+	var hidden = "<input type=\"hidden\" id=\"csrf_token\" name=\"csrf_token\" value=\"secret\" autocomplete=\"off\">\r\n"
+	var expected = hidden +
+		"<p><label for=\"name\">Name</label>\r\n<input type=\"text\" id=\"name\" name=\"name\">\r\n</p>" +
+		"<p><label for=\"email\">Email</label>\r\n<input type=\"email\" id=\"email\" name=\"email\">\r\n</p>"
+	if string(f.AsP()) != expected {
+		t.Errorf("Expected \n%q\ngot \n%q", expected, f.AsP())
+	}
+	if string(f.RenderHidden()) != hidden {
+		t.Errorf("Expected \n%q\ngot \n%q", hidden, f.RenderHidden())
+	}
+
+	f.Field("csrf_token").AddError(errors.New("token <expired>"))
+	var errs = "<ul class=\"errorlist nonfield\">\r\n<li>(Hidden field csrf_token) token &lt;expired&gt;</li>\r\n</ul>\r\n"
+	if html := string(f.AsP()); !strings.HasPrefix(html, errs+hidden) {
+		t.Errorf("Expected the errors of hidden fields before the fields, got \n%q", html)
+	}
+	if got := string(f.RenderHidden()); got != errs+hidden {
+		t.Errorf("Expected \n%q\ngot \n%q", errs+hidden, got)
+	}
+}
+
+func TestDuplicateFieldNames(t *testing.T) {
+	var f = forms.Form{}
+	f.TextField("name", "", "", "", "first")
+	if err := f.AddFieldsStrict(forms.New("name")); err == nil {
+		t.Errorf("Expected AddFieldsStrict to reject a duplicate name")
+	}
+	if err := f.AddFieldsStrict(forms.New("a"), forms.New("a")); err == nil || f.HasField("a") {
+		t.Errorf("Expected duplicates within one call to be rejected without adding any field")
+	}
+
+	f.AddFields(forms.New("name", forms.WithValue("second")))
+	if f.Get("name").String() != "first" || f.Field("name").GetValue()[0] != "first" {
+		t.Errorf("Expected lookups to return the first field with a name")
+	}
+	f.Dedupe()
+	if len(f.Fields) != 1 || !f.HasField("name") {
+		t.Errorf("Expected Dedupe to drop the later duplicate, got %d fields", len(f.Fields))
+	}
+}
+
+func TestCaseInsensitiveLookups(t *testing.T) {
+	var f = &forms.Form{}
+	f.TextField("Email", "", "", "", "")
+	f.TextField("Name", "", "", "", "")
+	f.TextField("Age", "", "", "", "")
+	if !f.Fill(newPostRequest(url.Values{"email": {"john@example.com"}, "NAME": {"John"}})) {
+		t.Fatalf("Expected the form to be valid, got %s", f.Errors)
+	}
+	if f.Field("EMAIL") == nil || f.Get("email").String() != "john@example.com" || f.Get("name").String() != "John" {
+		t.Errorf("Expected mixed-case lookups to find the filled fields")
+	}
+	var email, name string
+	if err := f.Scan([]string{"eMail", "nAmE"}, &email, &name); err != nil || email != "john@example.com" || name != "John" {
+		t.Errorf("Expected Scan to match names case insensitively, got %q %q (%v)", email, name, err)
+	}
+	f.Disabled("AGE")
+	if !f.Field("Age").(*forms.Field).Disabled {
+		t.Errorf("Expected Disabled to match names case insensitively")
+	}
+	f.Without("age")
+	f.Only("EMAIL", "name")
+	if len(f.Fields) != 2 || f.HasField("Age") {
+		t.Errorf("Expected Without and Only to match names case insensitively, got %d fields", len(f.Fields))
+	}
+
+	f.CaseSensitive = true
+	f.Fill(newPostRequest(url.Values{"email": {"john@example.com"}}))
+	if f.Field("email") != nil || f.Get("Email").String() != "" {
+		t.Errorf("Expected case sensitive forms to only match exact names")
+	}
+}
+
+func TestFillValues(t *testing.T) {
+	var hooks []string
+	var f = forms.Form{
+		BeforeValid: func(r *request.Request, f *forms.Form) error {
+			hooks = append(hooks, "before")
+			return nil
+		},
+		AfterValid: func(r *request.Request, f *forms.Form) error {
+			hooks = append(hooks, "after")
+			if r != nil {
+				t.Errorf("Expected a nil request outside of HTTP fills")
+			}
+			return nil
+		},
+	}
+	f.TextField("name", "", "", "", "").Required = true
+	f.NumberField("age", "", "", "", 0)
+
+	if !f.FillValues(url.Values{"name": {"John"}, "age": {"42"}}) {
+		t.Fatalf("Expected the form to be valid, got %s", f.Errors)
+	}
+	if strings.Join(hooks, ",") != "before,after" {
+		t.Errorf("Expected both hooks to run, got %v", hooks)
+	}
+	if f.FillMap(map[string][]string{"age": {"42"}}) {
+		t.Errorf("Expected the missing required name to fail validation")
+	}
+}
+
+func TestModifyGeneratedField(t *testing.T) {
+	type Signup struct {
+		Name  string `form:"label:Name"`
+		Email string `form:"label:Email"`
+	}
+	fieldMap, fields, err := forms.GenerateFieldMapFromStruct(Signup{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fields) != 2 || fields[1] != fieldMap["Email"] {
+		t.Fatalf("Expected the map and slice to hold the same fields in order")
+	}
+
+	var f = forms.Form{}
+	for _, field := range fields {
+		f.AddFields(field)
+	}
+	if !f.FillValues(url.Values{"Email": {"not an email"}}) {
+		t.Fatalf("Expected the form to be valid before adding a validator")
+	}
+	err = f.ModifyField("Email", func(field *forms.Field) {
+		field.Validators = append(field.Validators, validators.Email)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.FillValues(url.Values{"Email": {"not an email"}}) {
+		t.Errorf("Expected the added email validator to reject the value")
+	}
+	if f.ModifyField("Missing", func(*forms.Field) {}) == nil {
+		t.Errorf("Expected an error for a missing field")
+	}
+}
+
+func TestNilForm(t *testing.T) {
+	var f *forms.Form
+	var typ = reflect.TypeOf(f)
+	for i := 0; i < typ.NumMethod(); i++ {
+		var method = typ.Method(i)
+		if _, ok := typ.Elem().MethodByName(method.Name); ok {
+			// Methods with a value receiver, such as AsP, dereference the form before they are called.
+			continue
+		}
+		var args = []reflect.Value{reflect.ValueOf(f)}
+		for j := 1; j < method.Type.NumIn(); j++ {
+			if method.Type.IsVariadic() && j == method.Type.NumIn()-1 {
+				break
+			}
+			args = append(args, reflect.Zero(method.Type.In(j)))
+		}
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("Expected %s to be safe on a nil form, got a panic: %v", method.Name, r)
+				}
+			}()
+			method.Func.Call(args)
+		}()
+	}
+
+	if !f.Empty() || f.Field("name") != nil || f.Get("name") != nil {
+		t.Errorf("Expected a nil form to be empty")
+	}
+	if html := (forms.Form{}).AsP(); html != "" {
+		t.Errorf("Expected an empty form to render nothing, got %s", html)
+	}
+	if f.Validate() || f.FillValues(url.Values{"name": {"John"}}) {
+		t.Errorf("Expected a nil form to never be valid")
+	}
+	var name string
+	if err := f.Scan([]string{"name"}, &name); !errors.Is(err, forms.ErrNilForm) {
+		t.Errorf("Expected ErrNilForm, got %v", err)
+	}
+	if field := f.TextField("name", "", "", "", ""); field == nil || field.Name != "name" {
+		t.Errorf("Expected constructors to return the field, got %v", field)
+	}
+}
+
+func TestRequireFields(t *testing.T) {
+	var f = &forms.Form{}
+	if !f.Empty() || !f.Validate() {
+		t.Errorf("Expected an empty form to be valid")
+	}
+	f.RequireFields("email", "password")
+	f.AddTextField("email")
+	if f.Empty() {
+		t.Errorf("Expected a form with fields not to be empty")
+	}
+	if f.FillValues(url.Values{"email": {"john@example.com"}}) || len(f.Errors) != 1 || f.Errors[0].Name != "password" || !errors.Is(f.Errors[0].FieldErr, forms.ErrMissingField) {
+		t.Errorf("Expected the missing password field to fail validation, got %v", f.Errors)
+	}
+	f = &forms.Form{}
+	f.RequireFields("email")
+	f.AddTextField("EMAIL")
+	if !f.FillValues(url.Values{"email": {"john@example.com"}}) {
+		t.Errorf("Expected the expected field to be matched like lookups, got %v", f.Errors)
+	}
+}
+
+func TestValidateOnly(t *testing.T) {
+	var f = &forms.Form{}
+	f.AddEmailField("email", forms.WithRequired())
+	f.AddPasswordField("password", forms.WithRequired())
+	f.AddPasswordField("confirm", forms.WithFieldValidators(forms.DifferentFrom("email")))
+	var other = f.AddTextField("other", forms.WithRequired())
+	other.DependsOn = &forms.Dependency{Field: "email", Values: []string{"other@example.com"}}
+
+	f.FillValues(url.Values{"email": {"not an address"}})
+	f.Errors = nil
+	if f.ValidateOnly("email") || len(f.Errors) != 1 || f.Errors[0].Name != "email" {
+		t.Errorf("Expected only the invalid email to be reported, got %v", f.Errors)
+	}
+
+	f.Field("email").SetValue([]string{"john@example.com"})
+	if !f.ValidateOnly("email") || len(f.Errors) != 0 || f.Field("email").(*forms.Field).HasError() {
+		t.Errorf("Expected the valid email to replace its errors, got %v", f.Errors)
+	}
+
+	f.Field("email").SetValue([]string{"other@example.com"})
+	if f.ValidateOnly("email") || len(f.Errors) != 1 || f.Errors[0].Name != "other" {
+		t.Errorf("Expected the field depending on the email to be validated, got %v", f.Errors)
+	}
+
+	f.Errors = nil
+	f.Field("confirm").SetValue([]string{"other@example.com"})
+	if f.ValidateOnly("confirm", "nope") || len(f.Errors) != 2 || f.Errors[0].Name != "nope" || f.Errors[1].Name != "confirm" {
+		t.Errorf("Expected the field validators of confirm and the unknown name to be reported, got %v", f.Errors)
+	}
+
+	var dates = &forms.Form{}
+	dates.AddField("start", forms.WithType("date"))
+	var end = dates.AddField("end", forms.WithType("date"), forms.WithFieldValidators(forms.AfterField("start", "2006-01-02")))
+	var custom = dates.AddField("custom", forms.WithFieldValidators(forms.FieldValidatorFunc(func(*forms.Form, forms.FormElement) error {
+		return errors.New("not referenced")
+	})))
+	dates.FillValues(url.Values{"start": {"2023-05-01"}, "end": {"2023-05-02"}, "custom": {"x"}})
+	dates.Errors = nil
+	custom.FormErrors = nil
+	dates.Field("start").SetValue([]string{"2023-05-03"})
+	if dates.ValidateOnly("start") || len(dates.Errors) != 1 || dates.Errors[0].Name != "end" || !end.HasError() || custom.HasError() {
+		t.Errorf("Expected the field comparing itself to start to be validated, got %v", dates.Errors)
+	}
+	dates.Field("start").SetValue([]string{"2023-05-01"})
+	if !dates.ValidateOnly("start") || end.HasError() {
+		t.Errorf("Expected the errors of end to be replaced, got %v", dates.Errors)
+	}
+}
+
+func TestErrorSummary(t *testing.T) {
+	var f = forms.Form{ShowErrorSummary: true}
+	f.AddFields(forms.New("email", forms.WithID("email-input"), forms.WithType(forms.TypeEmail), forms.WithRequired()))
+	if string(f.ErrorSummary()) != "" {
+		t.Errorf("Expected no summary without errors, got %s", f.ErrorSummary())
+	}
+	f.Validate()
+	f.AddError("", errors.New("<too many attempts>"))
+
+	var expected = "<div class=\"form-errors\" role=\"alert\"><ul>" +
+		"<li><a href=\"#email-input\">Email: Email is required</a></li>" +
+		"<li>&lt;too many attempts&gt;</li>" +
+		"</ul></div>\r\n"
+	if string(f.ErrorSummary()) != expected {
+		t.Errorf("Expected \n%q\ngot \n%q", expected, f.ErrorSummary())
+	}
+	if !strings.HasPrefix(string(f.AsP()), expected) {
+		t.Errorf("Expected AsP to start with the summary, got \n%s", f.AsP())
+	}
+}
+
+func TestQueryFilterForm(t *testing.T) {
+	var f = &forms.Form{}
+	f.AddSelectField("category", forms.WithMultiple(), forms.WithOptions([]forms.Option{
+		{Value: forms.NewValue("books"), Text: "Books"},
+		{Value: forms.NewValue("games"), Text: "Games", Selected: true},
+		{Value: forms.NewValue("music"), Text: "Music"},
+	}))
+	f.AddCheckboxField("in_stock")
+	f.AddTextField("q")
+	f.SubmitButton("search", "", "", "Search")
+	var r = httptest.NewRequest(http.MethodGet, "/?category=books&category=music&in_stock=on&q=go", nil)
+	if !f.Fill(request.NewRequest(nil, r, nil)) {
+		t.Fatalf("Expected the filter form to be valid, got %v", f.Errors)
+	}
+	var html = string(f.AsP())
+	for _, s := range []string{`<option value="books" selected>`, `<option value="games">`, `<option value="music" selected>`, ` checked`, `value="go"`} {
+		if !strings.Contains(html, s) {
+			t.Errorf("Expected the query values to be rendered (%s), got \n%s", s, html)
+		}
+	}
+
+	var query = f.AsQueryString()
+	if query.Encode() != "category=books&category=music&in_stock=on&q=go" {
+		t.Errorf("Expected the canonical query, got %s", query.Encode())
+	}
+
+	f.Fill(request.NewRequest(nil, httptest.NewRequest(http.MethodGet, "/?page=2&"+query.Encode(), nil), nil))
+	if f.AsQueryString().Encode() != query.Encode() {
+		t.Errorf("Expected the filters to survive a round trip, got %s", f.AsQueryString().Encode())
+	}
+}
+
+func TestFormValues(t *testing.T) {
+	var f = forms.Form{}
+	f.AddTextField("q")
+	f.AddSelectField("tag", forms.WithMultiple())
+	f.AddFileField("avatar")
+	f.AddHiddenField("page")
+	f.FillValues(url.Values{"q": {"go forms"}, "tag": {"a", "b"}, "avatar": {"ignored"}})
+
+	var values, files = f.Values()
+	if len(files) != 1 || files[0] != "avatar" {
+		t.Errorf("Expected the file field to be reported as skipped, got %v", files)
+	}
+	if _, ok := values["avatar"]; ok {
+		t.Errorf("Expected file fields to be skipped, got %v", values)
+	}
+	if len(values["tag"]) != 2 || values.Get("q") != "go forms" {
+		t.Errorf("Expected multiple values to be preserved, got %v", values)
+	}
+	if f.Encode() != "q=go+forms&tag=a&tag=b" {
+		t.Errorf("Expected the values to be encoded, got %s", f.Encode())
+	}
+}
+
+type nopReadSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopReadSeekCloser) Close() error { return nil }
+
+func TestStopOnFirstError(t *testing.T) {
+	var calls int
+	var expensive = func(validators.FormValue) error {
+		calls++
+		return errors.New("taken")
+	}
+	var f = &forms.Form{}
+	f.AddTextField("a", forms.WithRequired())
+	f.AddTextField("b", forms.WithValidators(expensive))
+	f.AddTextField("c", forms.WithValidators(expensive))
+
+	f.FillValues(url.Values{"b": {"x"}, "c": {"y"}})
+	if len(f.Errors) != 3 || calls != 2 || len(f.SkippedFields) != 0 {
+		t.Errorf("Expected all fields to be validated, got %d errors, %d calls and skipped %v", len(f.Errors), calls, f.SkippedFields)
+	}
+
+	calls = 0
+	f.StopOnFirstError = true
+	f.FillValues(url.Values{"b": {"x"}, "c": {"y"}})
+	if len(f.Errors) != 1 || f.Errors[0].Name != "a" || calls != 0 {
+		t.Errorf("Expected validation to stop at the first field, got %v and %d calls", f.Errors, calls)
+	}
+	if len(f.SkippedFields) != 2 || f.SkippedFields[0] != "b" || f.SkippedFields[1] != "c" {
+		t.Errorf("Expected the remaining fields to be skipped in order, got %v", f.SkippedFields)
+	}
+}
+
+func TestComputedField(t *testing.T) {
+	var f = &forms.Form{}
+	f.AddNumberField("quantity")
+	f.AddNumberField("price")
+	f.AddTextField("total", forms.WithRequired(), forms.WithComputed(func(form *forms.Form) string {
+		var quantity, _ = strconv.Atoi(form.Field("quantity").Value().String())
+		var price, _ = strconv.Atoi(form.Field("price").Value().String())
+		return strconv.Itoa(quantity * price)
+	}))
+
+	var r = newPostRequest(url.Values{"quantity": {"3"}, "price": {"5"}, "total": {"1"}})
+	if !f.Fill(r) {
+		t.Fatalf("Expected computed fields to be skipped by validation, got %v", f.Errors)
+	}
+	if v := f.Field("total").GetValue(); len(v) != 0 {
+		t.Errorf("Expected the tampered total to be ignored, got %v", v)
+	}
+	if values, _ := f.Values(); values.Has("total") {
+		t.Errorf("Expected the total to be excluded from Values, got %v", values)
+	}
+
+	var order = struct {
+		Quantity int    `form:"type:number"`
+		Price    int    `form:"type:number"`
+		Total    string `form:"type:text"`
+	}{Total: "unchanged"}
+	if err := f.ScanStruct(&order); err != nil {
+		t.Fatal(err)
+	}
+	if order.Quantity != 3 || order.Total != "unchanged" {
+		t.Errorf("Expected the total not to be scanned, got %+v", order)
+	}
+	f.Field("total").SetValue([]string{"1"})
+	var total = "unchanged"
+	if err := f.Scan([]string{"total"}, &total); err != nil || total != "unchanged" {
+		t.Errorf("Expected Scan to skip the computed field, got %q (%v)", total, err)
+	}
+
+	var html = string(f.AsP())
+	if !strings.Contains(html, `name="total" value="15"`) || !strings.Contains(html, "disabled") {
+		t.Errorf("Expected the total to render as a disabled input with the computed value, got %s", html)
+	}
+}
+
+// newMultipartRequest returns a POST request with the multipart body written by write.
+func newMultipartRequest(write func(w *multipart.Writer)) *http.Request {
+	var body bytes.Buffer
+	var w = multipart.NewWriter(&body)
+	write(w)
+	w.Close()
+	var r = httptest.NewRequest("POST", "/", &body)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	return r
+}
+
+// newUploadRequest returns a multipart request uploading content as file.bin in field.
+func newUploadRequest(field, content string) *http.Request {
+	return newMultipartRequest(func(w *multipart.Writer) {
+		var part, _ = w.CreateFormFile(field, "file.bin")
+		part.Write([]byte(content))
+	})
+}
+
+func TestValidateDisabledAndReadOnly(t *testing.T) {
+	var f = &forms.Form{}
+	f.AddEmailField("email", forms.WithRequired())
+	f.AddTextField("username", forms.WithValue("john"), forms.WithRequired()).ReadOnly = true
+	f.Disabled("email")
+	if !f.FillValues(url.Values{"username": {"john"}}) {
+		t.Errorf("Expected a required disabled field and an unchanged read-only field to pass, got %v", f.Errors)
+	}
+
+	f.ValidateDisabled = true
+	if f.FillValues(url.Values{"username": {"john"}}) || !f.Field("email").HasError() {
+		t.Errorf("Expected the disabled field to be validated when ValidateDisabled is set")
+	}
+
+	f.ValidateDisabled = false
+	if f.FillValues(url.Values{"username": {"admin"}}) {
+		t.Errorf("Expected a modified read-only field to fail")
+	}
+	if errs := f.Field("username").Errors(); len(errs) != 1 || errs[0].FieldErr.Error() != "cannot be modified" {
+		t.Errorf("Expected a \"cannot be modified\" error, got %v", errs)
+	}
+}
+
+func TestClearedFieldMatchesFreshField(t *testing.T) {
+	var tests = []struct {
+		name   string
+		opts   []forms.FieldOption
+		filled []string
+	}{
+		{"text", []forms.FieldOption{forms.WithMin(3)}, []string{"hello"}},
+		{"required", []forms.FieldOption{forms.WithRequired()}, []string{"hello"}},
+		{"number", []forms.FieldOption{forms.WithType(forms.TypeNumber), forms.WithMin(1)}, []string{"42"}},
+		{"select", []forms.FieldOption{forms.WithType(forms.TypeSelect), forms.WithMultiple(), forms.WithOptions([]forms.Option{
+			{Text: "A", Value: forms.NewValue("a")},
+			{Text: "B", Value: forms.NewValue("b"), Selected: true},
+		})}, []string{"a"}},
+		{"checkbox", []forms.FieldOption{forms.WithType(forms.TypeCheck)}, []string{"on"}},
+	}
+	var errString = func(err error) string {
+		if err == nil {
+			return ""
+		}
+		return err.Error()
+	}
+	for _, test := range tests {
+		var fresh = forms.New(test.name, test.opts...)
+		var cleared = forms.New(test.name, test.opts...)
+		cleared.SetValue(test.filled)
+		cleared.Clear()
+
+		if cleared.Value() != nil {
+			t.Errorf("%s: expected Clear to reset the value to nil, got %v", test.name, cleared.Value())
+		}
+		if a, b := fresh.Field().String(), cleared.Field().String(); a != b {
+			t.Errorf("%s: expected the same rendering, got \n%s\nand\n%s", test.name, a, b)
+		}
+		if a, b := errString(fresh.Validate()), errString(cleared.Validate()); a != b {
+			t.Errorf("%s: expected the same validation result, got %q and %q", test.name, a, b)
+		}
+
+		var freshDst, clearedDst = "initial", "initial"
+		var freshForm, clearedForm = forms.Form{}, forms.Form{}
+		freshForm.AddFields(fresh)
+		clearedForm.AddFields(cleared)
+		if err := freshForm.Scan(nil, &freshDst); err != nil {
+			t.Fatal(err)
+		}
+		if err := clearedForm.Scan(nil, &clearedDst); err != nil {
+			t.Fatal(err)
+		}
+		if freshDst != clearedDst {
+			t.Errorf("%s: expected the same scan result, got %q and %q", test.name, freshDst, clearedDst)
+		}
+	}
+}
+
+type hookCtxKey struct{}
+
+func TestValidateHooks(t *testing.T) {
+	var calls []string
+	var f = &forms.Form{}
+	f.AddTextField("name")
+	f.BeforeValid = func(r *request.Request, f *forms.Form) error {
+		calls = append(calls, "BeforeValid")
+		return nil
+	}
+	f.AfterValid = func(r *request.Request, f *forms.Form) error {
+		calls = append(calls, "AfterValid")
+		return nil
+	}
+	f.BeforeValidate = func(ctx context.Context, f *forms.Form) error {
+		calls = append(calls, "BeforeValidate:"+fmt.Sprint(ctx.Value(hookCtxKey{})))
+		return nil
+	}
+	f.AfterValidate = func(ctx context.Context, f *forms.Form) error {
+		calls = append(calls, "AfterValidate:"+fmt.Sprint(ctx.Value(hookCtxKey{})))
+		return nil
+	}
+
+	var r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=John"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r = r.WithContext(context.WithValue(r.Context(), hookCtxKey{}, "request"))
+	if !f.FillRequest(r) {
+		t.Fatalf("Expected the form to be valid, got %v", f.Errors)
+	}
+	var expected = "BeforeValid BeforeValidate:request AfterValid AfterValidate:request"
+	if got := strings.Join(calls, " "); got != expected {
+		t.Errorf("Expected the hooks to run as %q, got %q", expected, got)
+	}
+
+	calls = nil
+	f.FillCtx(context.WithValue(context.Background(), hookCtxKey{}, "ctx"), url.Values{"name": {"John"}})
+	if got := strings.Join(calls, " "); got != "BeforeValid BeforeValidate:ctx AfterValid AfterValidate:ctx" {
+		t.Errorf("Expected FillCtx to pass its context, got %q", got)
+	}
+
+	f.AfterValidate = func(ctx context.Context, f *forms.Form) error {
+		return errors.New("username is taken")
+	}
+	if f.FillValues(url.Values{"name": {"John"}}) || !strings.Contains(f.Errors.Error(), "Validation: username is taken") {
+		t.Errorf("Expected an error in AfterValidate to abort with a form-level error, got %v", f.Errors)
+	}
+
+	calls = nil
+	f.BeforeValidate = func(ctx context.Context, f *forms.Form) error {
+		return errors.New("rate limited")
+	}
+	if f.FillValues(url.Values{"name": {"John"}}) || !strings.Contains(f.Errors.Error(), "Validation: rate limited") {
+		t.Errorf("Expected an error in BeforeValidate to abort with a form-level error, got %v", f.Errors)
+	}
+	if got := strings.Join(calls, " "); got != "BeforeValid" {
+		t.Errorf("Expected validation to stop after BeforeValidate, got %q", got)
+	}
+}
+
+func TestStopOnFirstFieldError(t *testing.T) {
+	var f = &forms.Form{}
+	var field = f.AddTextField("username", forms.WithRequired(), forms.WithMax(3), forms.WithValidators(validators.Regex(`^[a-z]+$`, false)))
+
+	// All errors of a field are collected by default.
+	if f.FillValues(url.Values{"username": {"J0HNNY"}}) {
+		t.Fatalf("Expected an invalid value to fail")
+	}
+	var errs = f.Field("username").Errors()
+	if len(errs) != 2 || errs[0].Code != "max_length" || errs[1].Code != "regex" || len(f.Errors) != 2 {
+		t.Errorf("Expected the length and regex errors on the field and the form, got %v", errs)
+	}
+
+	// A missing required value is the only error reported.
+	if f.FillValues(url.Values{"username": {""}}) {
+		t.Fatalf("Expected an empty required value to fail")
+	}
+	if errs := f.Field("username").Errors(); len(errs) != 1 || errs[0].FieldErr.Error() != "Username is required" {
+		t.Errorf("Expected only the required error, got %v", errs)
+	}
+
+	field.StopOnFirstFieldError = true
+	if f.FillValues(url.Values{"username": {"J0HNNY"}}) {
+		t.Fatalf("Expected an invalid value to fail")
+	}
+	if errs := f.Field("username").Errors(); len(errs) != 1 || errs[0].Code != "max_length" {
+		t.Errorf("Expected validation to stop at the first error, got %v", errs)
+	}
+}
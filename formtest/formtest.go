@@ -0,0 +1,107 @@
+// Package formtest provides helpers for testing forms.
+//
+// The helpers only use the public API of the forms package.
+package formtest
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+	"github.com/Nigel2392/router/v3/request"
+)
+
+// A File to upload with NewPostRequest.
+type File struct {
+	Name    string
+	Content []byte
+}
+
+// NewPostRequest builds a POST request submitting the values and files.
+//
+// Without files, the body is url encoded, otherwise a multipart body is built.
+func NewPostRequest(values url.Values, files map[string]File) *http.Request {
+	if len(files) == 0 {
+		var r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(values.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return r
+	}
+
+	var body bytes.Buffer
+	var w = multipart.NewWriter(&body)
+	for _, name := range sortedKeys(values) {
+		for _, v := range values[name] {
+			w.WriteField(name, v)
+		}
+	}
+	for _, name := range sortedKeys(files) {
+		var part, _ = w.CreateFormFile(name, files[name].Name)
+		part.Write(files[name].Content)
+	}
+	w.Close()
+
+	var r = httptest.NewRequest(http.MethodPost, "/", &body)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	return r
+}
+
+// Submit fills the form with a POST request of the values, and reports whether it is valid.
+func Submit(form *forms.Form, values url.Values) bool {
+	return form.Fill(&request.Request{Request: NewPostRequest(values, nil)})
+}
+
+// AssertValid submits the values and fails the test if the form is invalid.
+func AssertValid(t testing.TB, form *forms.Form, values url.Values) {
+	t.Helper()
+	if !Submit(form, values) {
+		t.Errorf("expected form to be valid, got errors:\n%s", form.Errors)
+	}
+}
+
+// AssertInvalid submits the values and fails the test if the form is valid.
+func AssertInvalid(t testing.TB, form *forms.Form, values url.Values) {
+	t.Helper()
+	if Submit(form, values) {
+		t.Errorf("expected form to be invalid")
+	}
+}
+
+// AssertFieldError fails the test if the field has no error containing the message.
+func AssertFieldError(t testing.TB, form *forms.Form, name string, message string) {
+	t.Helper()
+	var field = form.Field(name)
+	if field == nil {
+		t.Errorf("form has no field named %q", name)
+		return
+	}
+	for _, err := range field.Errors() {
+		if strings.Contains(err.FieldErr.Error(), message) {
+			return
+		}
+	}
+	t.Errorf("expected field %q to have an error containing %q, got %v", name, message, field.Errors())
+}
+
+// RenderContains fails the test if the rendered form does not contain s.
+func RenderContains(t testing.TB, form *forms.Form, s string) {
+	t.Helper()
+	var rendered = string(form.AsP())
+	if !strings.Contains(rendered, s) {
+		t.Errorf("expected rendered form to contain %q, got:\n%s", s, rendered)
+	}
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	var keys = make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
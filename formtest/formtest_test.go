@@ -0,0 +1,38 @@
+package formtest_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+	"github.com/Nigel2392/forms/formtest"
+	"github.com/Nigel2392/router/v3/request"
+)
+
+func TestHelpers(t *testing.T) {
+	var newForm = func() *forms.Form {
+		var f = &forms.Form{}
+		f.EmailField("email", "email", "", "", "").Required = true
+		f.FileField("avatar", "avatar", "", "", "Avatar")
+		return f
+	}
+
+	var f = newForm()
+	formtest.AssertValid(t, f, url.Values{"email": {"john@example.com"}})
+	formtest.RenderContains(t, f, `name="email"`)
+
+	f = newForm()
+	formtest.AssertInvalid(t, f, url.Values{})
+	formtest.AssertFieldError(t, f, "email", "required")
+
+	f = newForm()
+	var r = formtest.NewPostRequest(url.Values{"email": {"john@example.com"}}, map[string]formtest.File{
+		"avatar": {Name: "avatar.png", Content: []byte("png")},
+	})
+	if !f.Fill(&request.Request{Request: r}) {
+		t.Fatalf("expected multipart submission to be valid, got %s", f.Errors)
+	}
+	if name, file := f.Field("avatar").GetFile(); name != "avatar.png" || file == nil {
+		t.Errorf("expected the uploaded file to be set, got %q", name)
+	}
+}
@@ -0,0 +1,137 @@
+package forms
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RenderString renders the form with the named renderer and normalizes the markup,
+// so it can be compared against a golden file, see the goldentest package.
+//
+// The renderers are "p" (AsP), "hidden" (RenderHidden) and "errors" (ErrorSummary).
+// Attributes are sorted by name, line endings are LF,
+// runs of whitespace are collapsed into a single space and empty lines are dropped.
+func (f *Form) RenderString(renderer string) (string, error) {
+	if f == nil {
+		return "", ErrNilForm
+	}
+	var html string
+	switch renderer {
+	case "p":
+		html = string(f.AsP())
+	case "hidden":
+		html = string(f.RenderHidden())
+	case "errors":
+		html = string(f.ErrorSummary())
+	default:
+		return "", fmt.Errorf("unknown renderer %q", renderer)
+	}
+	return normalizeHTML(html), nil
+}
+
+// normalizeHTML sorts the attributes of all tags and collapses the whitespace between them.
+func normalizeHTML(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	var b strings.Builder
+	for s != "" {
+		var i = strings.IndexByte(s, '<')
+		if i < 0 {
+			b.WriteString(s)
+			break
+		}
+		b.WriteString(s[:i])
+		s = s[i:]
+		var end = tagEnd(s)
+		if end < 0 {
+			b.WriteString(s)
+			break
+		}
+		b.WriteString(sortAttrs(s[:end+1]))
+		s = s[end+1:]
+	}
+
+	var lines = strings.Split(b.String(), "\n")
+	var out = lines[:0]
+	for _, line := range lines {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			out = append(out, line)
+		}
+	}
+	if len(out) == 0 {
+		return ""
+	}
+	return strings.Join(out, "\n") + "\n"
+}
+
+// tagEnd returns the index of the '>' closing the tag s starts with, skipping quoted values.
+func tagEnd(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i
+		}
+	}
+	return -1
+}
+
+// sortAttrs rewrites an opening tag with its attributes sorted by name.
+func sortAttrs(tag string) string {
+	if strings.HasPrefix(tag, "</") || strings.HasPrefix(tag, "<!") {
+		return tag
+	}
+	var body = tag[1 : len(tag)-1]
+	var closing = ">"
+	if strings.HasSuffix(body, "/") {
+		body, closing = body[:len(body)-1], "/>"
+	}
+	var name, rest, _ = strings.Cut(strings.TrimSpace(body), " ")
+	type attr struct{ name, raw string }
+	var attrs []attr
+	for {
+		rest = strings.TrimLeft(rest, " \t\n")
+		if rest == "" {
+			break
+		}
+		var n = strings.IndexAny(rest, " \t\n=")
+		if n < 0 {
+			attrs = append(attrs, attr{rest, rest})
+			break
+		}
+		if rest[n] != '=' {
+			attrs = append(attrs, attr{rest[:n], rest[:n]})
+			rest = rest[n:]
+			continue
+		}
+		var end = len(rest)
+		if v := rest[n+1:]; v != "" && (v[0] == '"' || v[0] == '\'') {
+			if q := strings.IndexByte(v[1:], v[0]); q >= 0 {
+				end = n + q + 3
+			}
+		} else if s := strings.IndexAny(v, " \t\n"); s >= 0 {
+			end = n + 1 + s
+		}
+		attrs = append(attrs, attr{rest[:n], rest[:end]})
+		rest = rest[end:]
+	}
+	sort.SliceStable(attrs, func(i, j int) bool {
+		return attrs[i].name < attrs[j].name
+	})
+	var b strings.Builder
+	b.WriteString("<")
+	b.WriteString(name)
+	for _, a := range attrs {
+		b.WriteString(" ")
+		b.WriteString(a.raw)
+	}
+	b.WriteString(closing)
+	return b.String()
+}
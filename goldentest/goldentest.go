@@ -0,0 +1,134 @@
+// Package goldentest compares rendered forms against golden files in testdata.
+//
+// Run the tests with -update to write the golden files from the current output:
+//
+//	go test -run TestGolden . -update
+package goldentest
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// The number of unchanged lines shown around the differences of a mismatch.
+const contextLines = 3
+
+// Path returns the path of the named golden file, testdata/<name>.golden.
+func Path(name string) string {
+	return filepath.Join("testdata", name+".golden")
+}
+
+// Assert fails the test if got differs from the named golden file, showing a diff of the lines.
+//
+// With -update, the golden file is written instead.
+func Assert(t testing.TB, name string, got string) {
+	t.Helper()
+	var path = Path(name)
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	var want, err = os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("golden file %s does not exist, run the test with -update to create it", path)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(want) != got {
+		t.Errorf("output does not match %s:\n%s", path, Diff(string(want), got))
+	}
+}
+
+// AssertForm renders the form with RenderString and compares it against the named golden file.
+func AssertForm(t testing.TB, form *forms.Form, renderer string, name string) {
+	t.Helper()
+	var got, err = form.RenderString(renderer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Assert(t, name, got)
+}
+
+// Diff returns the lines removed from want ("-") and added in got ("+"),
+// with contextLines unchanged lines around each change.
+func Diff(want, got string) string {
+	var a = strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	var b = strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+
+	// The length of the longest common subsequence of a[i:] and b[j:].
+	var lcs = make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	type line struct {
+		op   byte
+		text string
+	}
+	var lines []line
+	var i, j int
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, line{' ', a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, line{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, line{'+', b[j]})
+			j++
+		}
+	}
+
+	var show = make([]bool, len(lines))
+	for n, l := range lines {
+		if l.op == ' ' {
+			continue
+		}
+		for k := n - contextLines; k <= n+contextLines; k++ {
+			if k >= 0 && k < len(lines) {
+				show[k] = true
+			}
+		}
+	}
+	var out strings.Builder
+	for n, l := range lines {
+		if !show[n] {
+			if n > 0 && show[n-1] {
+				out.WriteString("...\n")
+			}
+			continue
+		}
+		fmt.Fprintf(&out, "%c %s\n", l.op, l.text)
+	}
+	return out.String()
+}
@@ -0,0 +1,19 @@
+package goldentest_test
+
+import (
+	"testing"
+
+	"github.com/Nigel2392/forms/goldentest"
+)
+
+func TestDiff(t *testing.T) {
+	var want = "a\nb\nc\nd\ne\nf\ng\nh\ni\n"
+	var got = "a\nb\nc\nd\nE\nf\ng\nh\ni\n"
+	var expected = "  b\n  c\n  d\n- e\n+ E\n  f\n  g\n  h\n...\n"
+	if diff := goldentest.Diff(want, got); diff != expected {
+		t.Errorf("Expected the change with 3 lines of context, got\n%s", diff)
+	}
+	if diff := goldentest.Diff("a\n", "a\nb\n"); diff != "  a\n+ b\n" {
+		t.Errorf("Expected an added line, got\n%s", diff)
+	}
+}
@@ -0,0 +1,66 @@
+package forms
+
+import (
+	"html/template"
+	"strings"
+)
+
+// A GroupView holds the fields of a tab or section, see Form.Groups.
+type GroupView struct {
+	Name   string
+	Fields []FormElement
+}
+
+// fieldGroup returns the Group of the field, custom form elements are ungrouped.
+func fieldGroup(field FormElement) string {
+	if fld, ok := asField(field); ok {
+		return fld.Group
+	}
+	return ""
+}
+
+// Groups returns the fields of the form grouped by their Group, in the order the groups are first seen.
+//
+// Ungrouped fields belong to the "" group, which is returned last.
+func (f *Form) Groups() []GroupView {
+	if f == nil {
+		return nil
+	}
+	var groups []GroupView
+	var index = make(map[string]int)
+	var ungrouped []FormElement
+	for _, field := range f.Fields {
+		var name = fieldGroup(field)
+		if name == "" {
+			ungrouped = append(ungrouped, field)
+			continue
+		}
+		var i, ok = index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			groups = append(groups, GroupView{Name: name})
+		}
+		groups[i].Fields = append(groups[i].Fields, field)
+	}
+	if len(ungrouped) > 0 {
+		groups = append(groups, GroupView{Fields: ungrouped})
+	}
+	return groups
+}
+
+// RenderGroup renders the fields of the named group as paragraphs,
+// for templates which lay out the markup of their tabs themselves.
+func (f *Form) RenderGroup(name string) template.HTML {
+	if f == nil {
+		return ""
+	}
+	f.bind()
+	var b strings.Builder
+	for _, field := range f.Fields {
+		if fieldGroup(field) == name {
+			writeP(&b, field)
+		}
+	}
+	return template.HTML(b.String())
+}
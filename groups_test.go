@@ -0,0 +1,48 @@
+package forms_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestGroups(t *testing.T) {
+	type Settings struct {
+		Name   string `form:"group:Profile"`
+		Notes  string `form:"label:Notes"`
+		Email  string `form:"group:Notifications"`
+		Avatar string `form:"group:Profile"`
+	}
+	var fields, err = forms.GenerateFieldsFromStruct(Settings{Name: "John"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var f = &forms.Form{}
+	for _, field := range fields {
+		f.AddFields(field)
+	}
+	f.AddPasswordField("password", forms.WithGroup("Security"))
+
+	var groups = f.Groups()
+	var got []string
+	for _, group := range groups {
+		var names []string
+		for _, field := range group.Fields {
+			names = append(names, field.GetName())
+		}
+		got = append(got, group.Name+":"+strings.Join(names, ","))
+	}
+	var expected = "Profile:Name,Avatar Notifications:Email Security:password :Notes"
+	if strings.Join(got, " ") != expected {
+		t.Errorf("Expected groups %q, got %q", expected, strings.Join(got, " "))
+	}
+
+	var html = string(f.RenderGroup("Profile"))
+	if !strings.Contains(html, `name="Name"`) || !strings.Contains(html, `name="Avatar"`) || strings.Contains(html, `name="Notes"`) {
+		t.Errorf("Expected only the fields of the Profile group, got %s", html)
+	}
+	if html = string(f.RenderGroup("")); !strings.Contains(html, `name="Notes"`) || strings.Contains(html, `name="Email"`) {
+		t.Errorf("Expected only the ungrouped fields, got %s", html)
+	}
+}
@@ -0,0 +1,86 @@
+package forms
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// HTMX sets the hx-post, hx-target and hx-swap attributes of the form tag rendered by form_open,
+// empty arguments are left out.
+func (f *Form) HTMX(post string, target string, swap string) *Form {
+	if f == nil {
+		return nil
+	}
+	if f.HXAttrs == nil {
+		f.HXAttrs = make(map[string]string)
+	}
+	setHX(f.HXAttrs, "hx-post", post)
+	setHX(f.HXAttrs, "hx-target", target)
+	setHX(f.HXAttrs, "hx-swap", swap)
+	return f
+}
+
+// ValidateOnBlur posts the field to the endpoint when it loses focus after being changed,
+// replacing the contents of the field's error container with the response.
+//
+// The container, <div id="{id}-errors">, is rendered in place of the field's errors by AsP and form_errors,
+// even when the field has no errors.
+// The endpoint can validate the field with Form.ValidateField, and respond with Form.RenderErrors.
+func (f *Field) ValidateOnBlur(endpoint string) *Field {
+	if f.HXAttrs == nil {
+		f.HXAttrs = make(map[string]string)
+	}
+	f.HXAttrs["hx-post"] = endpoint
+	f.HXAttrs["hx-trigger"] = "blur changed"
+	f.HXAttrs["hx-swap"] = "innerHTML"
+	f.validateOnBlur = true
+	return f
+}
+
+func (f *Field) errorsID() string {
+	return f.EffectiveID() + "-errors"
+}
+
+// ValidateField fills and validates a single field from the values, returning the messages of its errors.
+//
+// The errors are added to the field, but not to the form, and other fields are left untouched,
+// so validators which compare fields, such as cross-field rules, are not run.
+func (f *Form) ValidateField(name string, values url.Values) (errors []string) {
+	if f == nil {
+		return []string{ErrNilForm.Error()}
+	}
+	var field = f.Field(name)
+	if field == nil {
+		return []string{fmt.Sprintf("no field named %q", name)}
+	}
+	if isComputed(field) || f.skipDisabled(field) {
+		return nil
+	}
+	var fld, ok = asField(field)
+	if ok {
+		fld.FormErrors = nil
+	}
+	f.fill(field, f.submitted(field, values))
+	if n, ok := field.(normalizer); ok {
+		if err := n.normalize(); err != nil {
+			field.AddError(err)
+			return []string{err.Error()}
+		}
+	}
+	var err = f.validateField(field)
+	if err == nil {
+		return nil
+	}
+	for _, err := range splitErrors(err) {
+		err = f.translate(err)
+		field.AddError(err)
+		errors = append(errors, err.Error())
+	}
+	return errors
+}
+
+func setHX(attrs map[string]string, name, value string) {
+	if value != "" {
+		attrs[name] = value
+	}
+}
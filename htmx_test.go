@@ -0,0 +1,59 @@
+package forms_test
+
+import (
+	"html/template"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestHTMX(t *testing.T) {
+	var f = &forms.Form{}
+	f.HTMX("/signup", "#result", "")
+	var email = f.AddEmailField("email", forms.WithRequired())
+	email.ValidateOnBlur("/signup/validate")
+	email.HXAttrs["hx-indicator"] = `#spinner"`
+	f.AddTextField("name", forms.WithRequired())
+
+	var tmpl = template.Must(template.New("").Funcs(forms.FuncMap()).Parse(`{{ form_open .Form "/signup" }}`))
+	var b strings.Builder
+	if err := tmpl.Execute(&b, map[string]any{"Form": f}); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != `<form action="/signup" method="post" hx-post="/signup" hx-target="#result">` {
+		t.Errorf("Expected the hx attributes on the form tag, got %s", b.String())
+	}
+
+	var html = email.Field().String()
+	for _, attr := range []string{`hx-post="/signup/validate"`, `hx-trigger="blur changed"`, `hx-target="#email-errors"`, `hx-swap="innerHTML"`, `hx-indicator="#spinner&#34;"`} {
+		if !strings.Contains(html, attr) {
+			t.Errorf("Expected %s on the field, got %s", attr, html)
+		}
+	}
+
+	if html = string(f.AsP()); !strings.Contains(html, `<div id="email-errors"></div><p>`) {
+		t.Errorf("Expected an empty error container before the field, got %s", html)
+	}
+
+	if errs := f.ValidateField("email", url.Values{"email": {"not an address"}}); len(errs) != 1 || !email.HasError() {
+		t.Errorf("Expected a single error for the invalid address, got %v", errs)
+	}
+	var errs = string(f.RenderErrors("email"))
+	if html = string(f.AsP()); !strings.Contains(html, `<div id="email-errors">`+errs+`</div><p`) || strings.Count(html, "errorlist") != 1 {
+		t.Errorf("Expected the errors inside of the container, got %s", html)
+	}
+	if strings.Contains(errs, "email-errors") {
+		t.Errorf("Expected RenderErrors to respond with the contents of the container, got %s", errs)
+	}
+	if errs := f.ValidateField("email", url.Values{"email": {"john@example.com"}}); len(errs) != 0 || email.HasError() {
+		t.Errorf("Expected the valid address to clear the errors, got %v", errs)
+	}
+	if len(f.Errors) != 0 || f.Field("name").(*forms.Field).HasError() {
+		t.Errorf("Expected the form and other fields to be left untouched, got %v", f.Errors)
+	}
+	if errs := f.ValidateField("emial", nil); len(errs) != 1 || !strings.Contains(errs[0], "no field named") {
+		t.Errorf("Expected an unknown field to be reported, got %v", errs)
+	}
+}
@@ -0,0 +1,130 @@
+package forms
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrReplayed is the FillError of a form whose idempotency token was already consumed,
+// e.g. by a double-clicked submit button. Handlers should respond as they did to the first submission.
+var ErrReplayed = errors.New("form was already submitted")
+
+// The name of the hidden field added by Form.IdempotencyToken.
+const IdempotencyFieldName = "idempotency_token"
+
+// A TokenStore consumes the single-use tokens of Form.IdempotencyToken.
+type TokenStore interface {
+	// Consume marks the token as used, reporting whether it was not used before.
+	Consume(token string) (bool, error)
+}
+
+// A TokenReleaser is a TokenStore which can make a consumed token usable again.
+//
+// The token is consumed before AfterValid and AfterValidate run, so a concurrent replay is rejected while they save the submission.
+// If either of them fails, the token is released so the user can retry, when the store implements TokenReleaser.
+type TokenReleaser interface {
+	TokenStore
+	Release(token string) error
+}
+
+// IdempotencyToken adds a hidden field holding a new single-use token.
+//
+// The token is consumed through store once the form was filled and validated,
+// submitting it again, or without a token, sets FillError to ErrReplayed.
+// The token of an invalid submission is not consumed, neither is the token of a multi-step form before its last step.
+// If AfterValid or AfterValidate fail, the token is released again when the store implements TokenReleaser.
+func (f *Form) IdempotencyToken(store TokenStore) *Form {
+	if f == nil {
+		return nil
+	}
+	var token = newToken()
+	var field = newField(TypeHidden, IdempotencyFieldName, IdempotencyFieldName, "", "", token, WithLabel(""), WithAutocomplete(AutocompleteOff))
+	f.AddFields(field)
+	f.tokenStore = store
+	return f
+}
+
+// consumeToken consumes the submitted idempotency token, if the form has one.
+func (f *Form) consumeToken() error {
+	if f.tokenStore == nil {
+		return nil
+	}
+	var token string
+	if field := f.Field(IdempotencyFieldName); field != nil && len(field.GetValue()) > 0 {
+		token = field.GetValue()[0]
+	}
+	if token == "" {
+		return ErrReplayed
+	}
+	var ok, err = f.tokenStore.Consume(token)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrReplayed
+	}
+	return nil
+}
+
+// releaseToken releases the consumed idempotency token after a hook failed, see TokenReleaser.
+func (f *Form) releaseToken() {
+	var store, ok = f.tokenStore.(TokenReleaser)
+	if !ok {
+		return
+	}
+	if field := f.Field(IdempotencyFieldName); field != nil && len(field.GetValue()) > 0 {
+		store.Release(field.GetValue()[0])
+	}
+}
+
+func newToken() string {
+	var b = make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// MemoryTokenStore is an in-memory TokenStore for tests and small, single-process applications.
+//
+// Consumed tokens are remembered for TTL, after which they are accepted again.
+type MemoryTokenStore struct {
+	TTL time.Duration
+
+	mu       sync.Mutex
+	consumed map[string]time.Time
+}
+
+// NewMemoryTokenStore returns a MemoryTokenStore remembering consumed tokens for ttl.
+func NewMemoryTokenStore(ttl time.Duration) *MemoryTokenStore {
+	return &MemoryTokenStore{TTL: ttl}
+}
+
+func (s *MemoryTokenStore) Consume(token string) (bool, error) {
+	var now = time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.consumed == nil {
+		s.consumed = make(map[string]time.Time)
+	}
+	for t, expires := range s.consumed {
+		if now.After(expires) {
+			delete(s.consumed, t)
+		}
+	}
+	if _, ok := s.consumed[token]; ok {
+		return false, nil
+	}
+	s.consumed[token] = now.Add(s.TTL)
+	return true, nil
+}
+
+func (s *MemoryTokenStore) Release(token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.consumed, token)
+	return nil
+}
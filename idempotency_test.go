@@ -0,0 +1,84 @@
+package forms_test
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nigel2392/forms"
+	"github.com/Nigel2392/router/v3/request"
+)
+
+func TestIdempotencyToken(t *testing.T) {
+	var store = forms.NewMemoryTokenStore(time.Minute)
+	var f = &forms.Form{}
+	f.AddTextField("q", forms.WithRequired())
+	f.IdempotencyToken(store)
+	var token = f.Field(forms.IdempotencyFieldName).GetValue()[0]
+	if token == "" || token == (&forms.Form{}).IdempotencyToken(store).Field(forms.IdempotencyFieldName).GetValue()[0] {
+		t.Fatalf("Expected a new token for every form, got %q", token)
+	}
+	if html := string(f.AsP()); !strings.Contains(html, `name="idempotency_token"`) || !strings.Contains(html, token) {
+		t.Errorf("Expected the token to be rendered as a hidden field, got %s", html)
+	}
+
+	if f.FillValues(url.Values{"q": {""}, forms.IdempotencyFieldName: {token}}) || f.FillError != nil {
+		t.Errorf("Expected an invalid submission to fail validation only, got %v", f.FillError)
+	}
+	if !f.FillValues(url.Values{"q": {"go"}, forms.IdempotencyFieldName: {token}}) {
+		t.Fatalf("Expected the token of an invalid submission not to be consumed, got %v", f.FillError)
+	}
+	if f.FillValues(url.Values{"q": {"go"}, forms.IdempotencyFieldName: {token}}) || !errors.Is(f.FillError, forms.ErrReplayed) {
+		t.Errorf("Expected a replayed token to fail with ErrReplayed, got %v", f.FillError)
+	}
+	if f.FillValues(url.Values{"q": {"go"}}) || !errors.Is(f.FillError, forms.ErrReplayed) {
+		t.Errorf("Expected a missing token to fail with ErrReplayed, got %v", f.FillError)
+	}
+
+	var expiring = forms.NewMemoryTokenStore(-time.Second)
+	expiring.Consume("token")
+	if ok, _ := expiring.Consume("token"); !ok {
+		t.Error("Expected expired tokens to be forgotten")
+	}
+}
+
+type consumeOnlyStore struct {
+	store *forms.MemoryTokenStore
+}
+
+func (s consumeOnlyStore) Consume(token string) (bool, error) {
+	return s.store.Consume(token)
+}
+
+func TestIdempotencyTokenFailedSave(t *testing.T) {
+	var saveErr = errors.New("database is down")
+	for _, test := range []struct {
+		name  string
+		store forms.TokenStore
+		retry bool
+	}{
+		{"releasing store", forms.NewMemoryTokenStore(time.Minute), true},
+		{"consuming store", consumeOnlyStore{forms.NewMemoryTokenStore(time.Minute)}, false},
+	} {
+		var fail = true
+		var f = &forms.Form{}
+		f.AddTextField("q")
+		f.IdempotencyToken(test.store)
+		f.AfterValid = func(r *request.Request, f *forms.Form) error {
+			if fail {
+				return saveErr
+			}
+			return nil
+		}
+		var values = url.Values{"q": {"go"}, forms.IdempotencyFieldName: {"token"}}
+		if f.FillValues(values); !f.Errors.HasError("Validation", saveErr) {
+			t.Errorf("%s: expected the failed save to be reported, got %v", test.name, f.Errors)
+		}
+		fail = false
+		if f.FillValues(values); (f.FillError == nil) != test.retry {
+			t.Errorf("%s: expected retry=%t after a failed save, got %v", test.name, test.retry, f.FillError)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package forms
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DefaultLabeler derives the label text of a field from its name.
+//
+// It is used by New, the form's field constructors and GenerateFieldsFromStruct
+// when no label is given, see SplitWords for the default behaviour.
+// Use Form.Labeler to override it for a single form.
+var DefaultLabeler func(fieldName string) string = SplitWords
+
+// SplitWords splits CamelCase, snake_case and kebab-case names into title-cased words.
+//
+// "FirstName" becomes "First Name", "created_at" becomes "Created At" and "UserID" becomes "User ID".
+func SplitWords(name string) string {
+	var b strings.Builder
+	b.Grow(len(name) + 4)
+	var runes = []rune(name)
+	var startWord = true
+	for i, r := range runes {
+		if r == '_' || r == '-' || unicode.IsSpace(r) {
+			startWord = true
+			continue
+		}
+		if i > 0 && unicode.IsUpper(r) {
+			var prev = runes[i-1]
+			var nextLower = i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && nextLower {
+				startWord = true
+			}
+		}
+		if startWord {
+			if b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			r = unicode.ToUpper(r)
+			startWord = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// relabel replaces a label derived by DefaultLabeler with the one derived by the form's Labeler.
+func (f *Form) relabel(fld *Field) {
+	if f.Labeler == nil || fld.Name == "" || fld.LabelText != DefaultLabeler(fld.Name) {
+		return
+	}
+	fld.LabelText = f.Labeler(fld.Name)
+}
@@ -0,0 +1,45 @@
+package forms_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestDefaultLabeler(t *testing.T) {
+	var tests = map[string]string{
+		"FirstName":  "First Name",
+		"created_at": "Created At",
+		"UserID":     "User ID",
+		"HTTPServer": "HTTP Server",
+		"email":      "Email",
+		"zip-code":   "Zip Code",
+		"Address2":   "Address2",
+	}
+	for in, expected := range tests {
+		if got := forms.DefaultLabeler(in); got != expected {
+			t.Errorf("Expected %q to be labeled %q, got %q", in, expected, got)
+		}
+	}
+
+	type Signup struct {
+		FirstName string `form:"required"`
+		LastName  string `form:"label:Surname"`
+	}
+	fields, err := forms.GenerateFieldsFromStruct(Signup{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields[0].LabelText != "First Name" || fields[1].LabelText != "Surname" {
+		t.Errorf("Expected derived and tagged labels, got %q and %q", fields[0].LabelText, fields[1].LabelText)
+	}
+
+	var f = forms.Form{Labeler: strings.ToUpper}
+	f.AddFields(fields[0], fields[1])
+	f.TextField("nickname", "nickname", "", "", "")
+	if fields[0].LabelText != "FIRSTNAME" || fields[1].LabelText != "Surname" || f.Field("nickname").(*forms.Field).LabelText != "NICKNAME" {
+		t.Errorf("Expected the form's labeler to replace derived labels only, got %q, %q and %q",
+			fields[0].LabelText, fields[1].LabelText, f.Field("nickname").(*forms.Field).LabelText)
+	}
+}
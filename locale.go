@@ -0,0 +1,125 @@
+package forms
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NumberLocale describes how numbers are formatted for display in a locale.
+//
+// Its Format and Normalize methods can be used as a field's DisplayFormatter and SubmitNormalizer.
+type NumberLocale struct {
+	Thousands string
+	Decimal   string
+}
+
+var (
+	// 1,234.56
+	LocaleEN = NumberLocale{Thousands: ",", Decimal: "."}
+	// 1.234,56
+	LocaleNL = NumberLocale{Thousands: ".", Decimal: ","}
+	// 1.234,56
+	LocaleDE = NumberLocale{Thousands: ".", Decimal: ","}
+	// 1 234,56
+	LocaleFR = NumberLocale{Thousands: " ", Decimal: ","}
+)
+
+// Format a plain numeric string ("1234.56") for display ("1,234.56").
+//
+// Values which are not plain numbers are returned unchanged.
+func (l NumberLocale) Format(s string) string {
+	if _, err := strconv.ParseFloat(s, 64); err != nil {
+		return s
+	}
+	var sign string
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		sign, s = s[:1], s[1:]
+	}
+	var intPart, fracPart, hasFrac = strings.Cut(s, ".")
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(l.Thousands)
+		}
+		b.WriteRune(c)
+	}
+	if hasFrac {
+		b.WriteString(l.Decimal)
+		b.WriteString(fracPart)
+	}
+	return b.String()
+}
+
+// Normalize a localized numeric string ("1.234,56") into a plain numeric string ("1234.56").
+func (l NumberLocale) Normalize(s string) (string, error) {
+	var v = strings.TrimSpace(s)
+	if v == "" {
+		return v, nil
+	}
+	if l.Thousands != "" {
+		v = strings.ReplaceAll(v, l.Thousands, "")
+	}
+	v = strings.ReplaceAll(v, " ", "")
+	if l.Decimal != "." {
+		v = strings.Replace(v, l.Decimal, ".", 1)
+	}
+	if _, err := strconv.ParseFloat(v, 64); err != nil {
+		return s, fmt.Errorf("%s is not a valid number", s)
+	}
+	return v, nil
+}
+
+type normalizer interface {
+	normalize() error
+}
+
+// normalize runs the Sanitizer, strips the currency symbol and runs the SubmitNormalizer over all values of the field.
+func (f *Field) normalize() error {
+	if f.Sanitizer != nil {
+		f.RawSubmitted = nil
+	}
+	if f.FormValue == nil || f.SubmitNormalizer == nil && f.CurrencySymbol == "" && f.Sanitizer == nil {
+		return nil
+	}
+	if f.Sanitizer != nil {
+		f.RawSubmitted = append([]string(nil), f.FormValue.Val...)
+	}
+	for i, v := range f.FormValue.Val {
+		if f.Sanitizer != nil {
+			v = f.Sanitizer(v)
+		}
+		if f.CurrencySymbol != "" {
+			v = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(v), f.CurrencySymbol))
+		}
+		if f.SubmitNormalizer == nil {
+			f.FormValue.Val[i] = v
+			continue
+		}
+		var n, err = f.SubmitNormalizer(v)
+		if err != nil {
+			return err
+		}
+		f.FormValue.Val[i] = n
+	}
+	return nil
+}
+
+// normalize the submitted values of all fields, adding errors to the fields that fail.
+func (f *Form) normalize() bool {
+	var valid = true
+	for _, field := range f.Fields {
+		var n, ok = field.(normalizer)
+		if !ok {
+			continue
+		}
+		if err := n.normalize(); err != nil {
+			valid = false
+			f.AddError(field.GetName(), err)
+			f.fieldInvalid(f.Errors[len(f.Errors)-1])
+			field.AddError(err)
+		}
+	}
+	return valid
+}
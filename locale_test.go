@@ -0,0 +1,44 @@
+package forms_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestLocaleNumbers(t *testing.T) {
+	var f = forms.Form{}
+	var price = f.TextField("price", "price", "", "", "")
+	price.InputMode = "decimal"
+	price.DisplayFormatter = forms.LocaleNL.Format
+	price.SubmitNormalizer = forms.LocaleNL.Normalize
+
+	if !f.Fill(newPostRequest(url.Values{"price": {"1.234,56"}})) {
+		t.Fatalf("Expected the normalized price to be valid, got %s", f.Errors)
+	}
+	var value float64
+	if err := f.Scan([]string{"price"}, &value); err != nil || value != 1234.56 {
+		t.Errorf("Expected price to scan as 1234.56, got %v (%v)", value, err)
+	}
+	if !strings.Contains(price.Field().String(), `value="1.234,56"`) {
+		t.Errorf("Expected the price to be formatted for display, got %s", price.Field().String())
+	}
+
+	if f.Fill(newPostRequest(url.Values{"price": {"12,34,56"}})) {
+		t.Errorf("Expected an invalid number to fail normalization")
+	}
+
+	for _, typ := range []string{forms.TypeNumber, forms.TypeRange} {
+		var amount = forms.NewField("amount", typ, "Amount")
+		amount.DisplayFormatter = forms.LocaleNL.Format
+		amount.SetValue([]string{"1234.5"})
+		if html := amount.Field().String(); !strings.Contains(html, `value="1234.5"`) {
+			t.Errorf("Expected the %s input to render the plain number, got %s", typ, html)
+		}
+	}
+	if forms.LocaleEN.Format("-1234567.5") != "-1,234,567.5" {
+		t.Errorf("Expected -1,234,567.5, got %s", forms.LocaleEN.Format("-1234567.5"))
+	}
+}
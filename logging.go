@@ -0,0 +1,107 @@
+package forms
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// An EventLogger receives the lifecycle events of a form, such as a *slog.Logger.
+//
+// Events carry the name of the form, field names and error codes, never submitted values.
+type EventLogger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+}
+
+// Metrics observes the outcome of validating a form, keyed by Form.Name.
+type Metrics interface {
+	ObserveValidation(formName string, valid bool, d time.Duration)
+}
+
+// fillStarted logs the start of a fill, returning the time it started.
+func (f *Form) fillStarted() time.Time {
+	if f.Logger != nil {
+		f.Logger.Debug("form fill started", "form", f.Name)
+	}
+	return time.Now()
+}
+
+// fieldInvalid logs a validation error of a field by its code, the message may contain the submitted value.
+func (f *Form) fieldInvalid(err FormError) {
+	if f.Logger != nil {
+		f.Logger.Debug("form field invalid", "form", f.Name, "field", err.Name, "code", err.Code)
+	}
+}
+
+// observe logs the outcome of a fill or validation which started at start, and reports it to the Metrics.
+func (f *Form) observe(start time.Time, valid bool) {
+	var d = time.Since(start)
+	if f.Logger != nil {
+		f.Logger.Info("form validated", "form", f.Name, "valid", valid, "errors", len(f.Errors), "duration", d)
+	}
+	if f.Metrics != nil {
+		f.Metrics.ObserveValidation(f.Name, valid, d)
+	}
+}
+
+// Replaces the values of sensitive fields in LogSafeValues, String and LogValue.
+const Redacted = "[redacted]"
+
+type sensitive interface {
+	IsSensitive() bool
+}
+
+func isSensitive(field FormElement) bool {
+	var s, ok = field.(sensitive)
+	return ok && s.IsSensitive()
+}
+
+// logValue returns the values of the field, or Redacted for sensitive fields.
+func logValue(field FormElement) []string {
+	if isSensitive(field) {
+		return []string{Redacted}
+	}
+	if field.IsFile() {
+		var name, _ = field.GetFile()
+		if name == "" {
+			return nil
+		}
+		return []string{name}
+	}
+	return field.GetValue()
+}
+
+// LogSafeValues returns the values of all fields keyed by name, safe to log.
+//
+// The values of sensitive fields, such as passwords and the CSRF token, are replaced with Redacted.
+// File fields hold the name of their file.
+func (f *Form) LogSafeValues() map[string][]string {
+	if f == nil {
+		return nil
+	}
+	var values = make(map[string][]string, len(f.Fields))
+	for _, field := range f.Fields {
+		values[field.GetName()] = logValue(field)
+	}
+	return values
+}
+
+// String describes the values of the form for logging, see LogSafeValues.
+func (f *Form) String() string {
+	if f == nil {
+		return "Form{}"
+	}
+	var b strings.Builder
+	b.WriteString("Form{")
+	for i, field := range f.Fields {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(field.GetName())
+		b.WriteString(": ")
+		fmt.Fprintf(&b, "%q", logValue(field))
+	}
+	b.WriteString("}")
+	return b.String()
+}
@@ -0,0 +1,26 @@
+//go:build go1.21
+
+package forms
+
+import "log/slog"
+
+// LogValue logs the values of the form as a group, see LogSafeValues.
+func (f *Form) LogValue() slog.Value {
+	if f == nil {
+		return slog.GroupValue()
+	}
+	var attrs = make([]slog.Attr, 0, len(f.Fields))
+	for _, field := range f.Fields {
+		attrs = append(attrs, slog.Any(field.GetName(), logValue(field)))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue logs the name, type and value of the field, redacting the value of sensitive fields.
+func (f *Field) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("name", f.Name),
+		slog.String("type", f.Type),
+		slog.Any("value", logValue(f)),
+	)
+}
@@ -0,0 +1,83 @@
+//go:build go1.21
+
+package forms_test
+
+import (
+	"bytes"
+	"log/slog"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nigel2392/forms"
+	"github.com/Nigel2392/forms/validators"
+)
+
+func TestLogValue(t *testing.T) {
+	var f = &forms.Form{}
+	f.AddTextField("username")
+	var password = f.AddPasswordField("password")
+	f.FillValues(url.Values{"username": {"john"}, "password": {"hunter2"}})
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("submitted", "form", f, "field", password)
+	if strings.Contains(buf.String(), "hunter2") {
+		t.Errorf("Expected the password to be redacted, got %s", buf.String())
+	}
+	if !strings.Contains(buf.String(), `"form":{"username":["john"],"password":["[redacted]"]}`) {
+		t.Errorf("Expected the form to be logged as a group, got %s", buf.String())
+	}
+	if !strings.Contains(buf.String(), `"field":{"name":"password","type":"password","value":["[redacted]"]}`) {
+		t.Errorf("Expected the field to be logged as a group, got %s", buf.String())
+	}
+}
+
+type validationMetrics struct {
+	form  string
+	valid []bool
+}
+
+func (m *validationMetrics) ObserveValidation(formName string, valid bool, d time.Duration) {
+	m.form = formName
+	m.valid = append(m.valid, valid)
+}
+
+func TestFormLogger(t *testing.T) {
+	var buf bytes.Buffer
+	var metrics = &validationMetrics{}
+	var f = &forms.Form{
+		Name:    "signup",
+		Logger:  slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		Metrics: metrics,
+	}
+	f.AddTextField("username", forms.WithRequired())
+	f.AddPasswordField("password", forms.WithValidators(validators.MinLength(12)))
+	f.FillValues(url.Values{"password": {"hunter2"}})
+
+	var logs = buf.String()
+	if strings.Contains(logs, "hunter2") {
+		t.Errorf("Expected the submitted values to never be logged, got %s", logs)
+	}
+	for _, expected := range []string{
+		`"level":"DEBUG","msg":"form fill started","form":"signup"`,
+		`"msg":"form field invalid","form":"signup","field":"username","code":"required"`,
+		`"msg":"form field invalid","form":"signup","field":"password","code":"min_length"`,
+		`"level":"INFO","msg":"form validated","form":"signup","valid":false,"errors":2,"duration":`,
+	} {
+		if !strings.Contains(logs, expected) {
+			t.Errorf("Expected the logs to contain %s, got %s", expected, logs)
+		}
+	}
+	if strings.Count(logs, "form validated") != 1 {
+		t.Errorf("Expected the outcome to be logged once per fill, got %s", logs)
+	}
+
+	f.Errors = nil
+	f.Field("username").SetValue([]string{"john"})
+	f.Field("password").SetValue([]string{"correct horse battery"})
+	f.Validate()
+	if metrics.form != "signup" || len(metrics.valid) != 2 || metrics.valid[0] || !metrics.valid[1] {
+		t.Errorf("Expected a failed fill and a valid validation to be observed, got %+v", metrics)
+	}
+}
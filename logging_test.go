@@ -0,0 +1,52 @@
+package forms_test
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestLogSafeValues(t *testing.T) {
+	var f = &forms.Form{}
+	f.CSRFToken("csrf-secret")
+	f.AddTextField("username")
+	var password = f.AddPasswordField("password")
+	f.AddTextField("api_key", forms.WithSensitive())
+	f.FillValues(url.Values{
+		"csrf_token": {"csrf-secret"}, "username": {"john"},
+		"password": {"hunter2"}, "api_key": {"key-secret"},
+	})
+
+	var values = f.LogSafeValues()
+	if got := values["username"]; len(got) != 1 || got[0] != "john" {
+		t.Errorf("Expected the username to be logged, got %v", got)
+	}
+	for _, name := range []string{"csrf_token", "password", "api_key"} {
+		if got := values[name]; len(got) != 1 || got[0] != forms.Redacted {
+			t.Errorf("Expected %s to be redacted, got %v", name, got)
+		}
+	}
+
+	var outputs = map[string]string{
+		"String":      f.String(),
+		"%v":          fmt.Sprintf("%v", f),
+		"field %v":    fmt.Sprintf("%v", password),
+		"field value": f.Field("password").Field().String(),
+	}
+	for name, out := range outputs {
+		for _, secret := range []string{"hunter2", "csrf-secret", "key-secret"} {
+			if name == "field %v" && secret != "hunter2" {
+				continue
+			}
+			if strings.Contains(out, secret) {
+				t.Errorf("Expected %s not to contain %q, got %s", name, secret, out)
+			}
+		}
+	}
+	if !strings.Contains(f.String(), `username: ["john"]`) {
+		t.Errorf("Expected non-sensitive values to be logged, got %s", f.String())
+	}
+}
@@ -0,0 +1,55 @@
+package forms
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Nigel2392/forms/validators"
+)
+
+// OptInt returns an option with an integer value, e.g. the ID of a category.
+func OptInt(value int, text string) Option {
+	return Option{Value: NewValue(strconv.Itoa(value)), Text: text}
+}
+
+// canonicalInt returns the canonical form of an integer value, "07" becomes "7".
+//
+// Values which are not integers are returned unchanged.
+func canonicalInt(s string) string {
+	var n, err = strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return s
+	}
+	return strconv.FormatInt(n, 10)
+}
+
+// NormalizeInt is a SubmitNormalizer which converts integers into their canonical form, "07" becomes "7".
+//
+// Values which are not integers are left unchanged, for the validators to reject.
+func NormalizeInt(s string) (string, error) {
+	return canonicalInt(s), nil
+}
+
+// NumberSelectField adds a select of options with integer values, see OptInt.
+//
+// Submitted values are normalized to their canonical form and restricted to the options,
+// so they can be scanned into int, int64 and uint fields.
+func (f *Form) NumberSelectField(name string, id string, classes string, options []Option) *Field {
+	var allowed = make([]string, 0, len(options))
+	for _, o := range options {
+		if o.Value == nil {
+			continue
+		}
+		for _, v := range o.Value.Val {
+			allowed = append(allowed, canonicalInt(v))
+		}
+	}
+	var field = newField(TypeSelect, name, id, classes, "", "", WithOptions(options))
+	field.SubmitNormalizer = NormalizeInt
+	field.intOptions = true
+	field.Validators = validators.New(
+		validators.OneOf(allowed...),
+	)
+	f.AddFields(field)
+	return field
+}
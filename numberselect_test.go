@@ -0,0 +1,58 @@
+package forms_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestNumberSelectField(t *testing.T) {
+	type Post struct {
+		Category int  `form:"label:Category;"`
+		Parent   uint `form:"label:Parent;"`
+	}
+
+	var f = &forms.Form{}
+	f.NumberSelectField("Category", "Category", "", []forms.Option{
+		forms.OptInt(3, "News"),
+		forms.OptInt(7, "Sports"),
+	})
+	f.NumberSelectField("Parent", "Parent", "", []forms.Option{
+		forms.OptInt(1, "Root"),
+	})
+	if !f.FillValues(url.Values{"Category": {"07"}, "Parent": {"1"}}) {
+		t.Fatalf("Expected the form to be valid, got %v", f.Errors)
+	}
+	if !strings.Contains(f.Field("Category").Field().String(), `<option value="7" selected>Sports</option>`) {
+		t.Errorf("Expected \"07\" to select the option with value 7, got %s", f.Field("Category").Field())
+	}
+	var p Post
+	if err := f.ScanStruct(&p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Category != 7 || p.Parent != 1 {
+		t.Errorf("Expected Category 7 and Parent 1, got %+v", p)
+	}
+
+	if f.FillValues(url.Values{"Category": {"5"}, "Parent": {"1"}}) {
+		t.Errorf("Expected a value which is not an option to be rejected")
+	}
+	if f.FillValues(url.Values{"Category": {"news"}, "Parent": {"1"}}) {
+		t.Errorf("Expected a value which is not a number to be rejected")
+	}
+}
+
+func TestSelectMatchesExactly(t *testing.T) {
+	var f = &forms.Form{}
+	f.AddSelectField("area", forms.WithOptions([]forms.Option{
+		{Value: forms.NewValue("01"), Text: "01"},
+		{Value: forms.NewValue("1"), Text: "1"},
+	}))
+	f.FillValues(url.Values{"area": {"01"}})
+	var html = f.Field("area").Field().String()
+	if !strings.Contains(html, `<option value="01" selected>`) || strings.Contains(html, `<option value="1" selected>`) {
+		t.Errorf("Expected only the exact option to be selected outside of a NumberSelectField, got %s", html)
+	}
+}
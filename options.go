@@ -0,0 +1,251 @@
+package forms
+
+import (
+	"github.com/Nigel2392/forms/validators"
+)
+
+// A FieldOption configures a field created with New.
+type FieldOption func(*Field)
+
+// New creates a new field with the given options applied in order.
+//
+// Without options, the field is a text field labeled by DefaultLabeler.
+func New(name string, opts ...FieldOption) *Field {
+	var field = &Field{
+		Type:      TypeText,
+		Name:      name,
+		LabelText: DefaultLabeler(name),
+	}
+	for _, opt := range opts {
+		opt(field)
+	}
+	return field
+}
+
+// WithType sets the type of the field.
+func WithType(typ string) FieldOption {
+	return func(f *Field) {
+		f.Type = typ
+	}
+}
+
+// WithID sets the ID of the field.
+func WithID(id string) FieldOption {
+	return func(f *Field) {
+		f.ID = id
+	}
+}
+
+// WithLabel sets the label text of the field, an empty label is not rendered.
+func WithLabel(label string) FieldOption {
+	return func(f *Field) {
+		f.LabelText = label
+	}
+}
+
+// WithPlaceholder sets the placeholder of the field.
+func WithPlaceholder(placeholder string) FieldOption {
+	return func(f *Field) {
+		f.Placeholder = placeholder
+	}
+}
+
+// WithClass sets the class of the field.
+func WithClass(class string) FieldOption {
+	return func(f *Field) {
+		f.Class = class
+	}
+}
+
+// WithValue sets the initial value of the field.
+func WithValue(value ...string) FieldOption {
+	return func(f *Field) {
+		f.FormValue = &FormData{Val: value}
+	}
+}
+
+// WithValidators appends validators to the field.
+func WithValidators(v ...validators.Validator) FieldOption {
+	return func(f *Field) {
+		f.Validators = append(f.Validators, v...)
+	}
+}
+
+// WithFieldValidators appends validators comparing the field to other fields of its form.
+func WithFieldValidators(v ...FieldValidator) FieldOption {
+	return func(f *Field) {
+		f.FieldValidators = append(f.FieldValidators, v...)
+	}
+}
+
+// WithOptions sets the options of a select field.
+func WithOptions(options []Option) FieldOption {
+	return func(f *Field) {
+		f.Options = options
+	}
+}
+
+// WithRequired marks the field as required.
+func WithRequired() FieldOption {
+	return func(f *Field) {
+		f.Required = true
+	}
+}
+
+// WithChecked sets the checked state of a checkbox or radio field.
+func WithChecked(checked bool) FieldOption {
+	return func(f *Field) {
+		f.SetChecked(checked)
+	}
+}
+
+// WithCheckValue sets the value a checked checkbox or radio submits.
+func WithCheckValue(value string) FieldOption {
+	return func(f *Field) {
+		f.CheckValue = value
+	}
+}
+
+// WithAutocomplete sets the autocomplete attribute of the field, e.g. AutocompleteNewPassword.
+func WithAutocomplete(value string) FieldOption {
+	return func(f *Field) {
+		f.Autocomplete = value
+	}
+}
+
+// WithComputed computes the value of the field at render time, see Field.Computed.
+func WithComputed(compute func(form *Form) string) FieldOption {
+	return func(f *Field) {
+		f.Computed = compute
+	}
+}
+
+// WithSanitizer sanitizes the submitted values of the field during Fill, e.g. with StripTags.
+func WithSanitizer(sanitize Sanitizer) FieldOption {
+	return func(f *Field) {
+		f.Sanitizer = sanitize
+	}
+}
+
+// WithGroup sets the tab or section the field is rendered in.
+func WithGroup(group string) FieldOption {
+	return func(f *Field) {
+		f.Group = group
+	}
+}
+
+// WithSensitive redacts the value of the field when it is logged.
+func WithSensitive() FieldOption {
+	return func(f *Field) {
+		f.Sensitive = true
+	}
+}
+
+// WithMin sets the lower bound of the field.
+func WithMin(min int) FieldOption {
+	return func(f *Field) {
+		f.SetMin(min)
+	}
+}
+
+// WithMax sets the upper bound of the field.
+func WithMax(max int) FieldOption {
+	return func(f *Field) {
+		f.SetMax(max)
+	}
+}
+
+// WithEmptyLabel adds a disabled placeholder option to a select field.
+func WithEmptyLabel(label string) FieldOption {
+	return func(f *Field) {
+		f.EmptyLabel = label
+	}
+}
+
+// WithMultiple allows selecting multiple options.
+func WithMultiple() FieldOption {
+	return func(f *Field) {
+		f.Multiple = true
+	}
+}
+
+// WithSelections sets the minimum and maximum number of selected options, 0 means no limit.
+func WithSelections(min, max int) FieldOption {
+	return func(f *Field) {
+		f.MinSelections = min
+		f.MaxSelections = max
+	}
+}
+
+// WithMaxFileSize sets the maximum size in bytes of an uploaded file.
+func WithMaxFileSize(size int64) FieldOption {
+	return func(f *Field) {
+		f.MaxFileSize = size
+	}
+}
+
+// WithAttrs adds extra attributes to the rendered field.
+func WithAttrs(attrs map[string]string) FieldOption {
+	return func(f *Field) {
+		if f.Attrs == nil {
+			f.Attrs = make(map[string]string, len(attrs))
+		}
+		for k, v := range attrs {
+			f.Attrs[k] = v
+		}
+	}
+}
+
+// AddField creates a field with New and adds it to the form.
+func (f *Form) AddField(name string, opts ...FieldOption) *Field {
+	var field = New(name, opts...)
+	f.AddFields(field)
+	return field
+}
+
+// AddTextField adds a text field configured by opts.
+func (f *Form) AddTextField(name string, opts ...FieldOption) *Field {
+	return f.AddField(name, append([]FieldOption{WithType(TypeText)}, opts...)...)
+}
+
+// AddPasswordField adds a password field configured by opts.
+//
+// It autocompletes the current password, pass WithAutocomplete(AutocompleteNewPassword) for sign-up forms.
+func (f *Form) AddPasswordField(name string, opts ...FieldOption) *Field {
+	return f.AddField(name, append([]FieldOption{WithType(TypePassword), WithAutocomplete(AutocompleteCurrentPassword)}, opts...)...)
+}
+
+// AddEmailField adds an email field, validating the address, configured by opts.
+func (f *Form) AddEmailField(name string, opts ...FieldOption) *Field {
+	return f.AddField(name, append([]FieldOption{WithType(TypeEmail), WithValidators(validators.Email)}, opts...)...)
+}
+
+// AddNumberField adds a number field configured by opts.
+func (f *Form) AddNumberField(name string, opts ...FieldOption) *Field {
+	return f.AddField(name, append([]FieldOption{WithType(TypeNumber)}, opts...)...)
+}
+
+// AddHiddenField adds a hidden field configured by opts.
+func (f *Form) AddHiddenField(name string, opts ...FieldOption) *Field {
+	return f.AddField(name, append([]FieldOption{WithType(TypeHidden)}, opts...)...)
+}
+
+// AddTextAreaField adds a textarea configured by opts.
+func (f *Form) AddTextAreaField(name string, opts ...FieldOption) *Field {
+	return f.AddField(name, append([]FieldOption{WithType(TypeTextArea)}, opts...)...)
+}
+
+// AddSelectField adds a select field configured by opts.
+func (f *Form) AddSelectField(name string, opts ...FieldOption) *Field {
+	return f.AddField(name, append([]FieldOption{WithType(TypeSelect)}, opts...)...)
+}
+
+// AddCheckboxField adds a checkbox configured by opts.
+func (f *Form) AddCheckboxField(name string, opts ...FieldOption) *Field {
+	return f.AddField(name, append([]FieldOption{WithType(TypeCheck)}, opts...)...)
+}
+
+// AddFileField adds a file field configured by opts.
+func (f *Form) AddFileField(name string, opts ...FieldOption) *Field {
+	return f.AddField(name, append([]FieldOption{WithType(TypeFile)}, opts...)...)
+}
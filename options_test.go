@@ -0,0 +1,29 @@
+package forms_test
+
+import (
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestFieldOptions(t *testing.T) {
+	var positional = forms.Form{}
+	positional.EmailField("email", "", "", "", "")
+
+	var options = forms.Form{}
+	var email = options.AddEmailField("email", forms.WithRequired(), forms.WithAttrs(map[string]string{"data-x": "1"}))
+	if len(email.Validators) != 1 || !email.Required {
+		t.Fatalf("Expected the email validator and required flag to be set")
+	}
+	email.Required = false
+	email.Attrs = nil
+	if positional.Fields[0].(*forms.Field).String() != email.String() {
+		t.Errorf("Expected positional and option constructors to render the same, got \n%q\n%q", positional.Fields[0].(*forms.Field).String(), email.String())
+	}
+
+	var field = forms.New("age", forms.WithType(forms.TypeNumber), forms.WithMax(10), forms.WithAttrs(map[string]string{"step": "2", "data-a": `"x"`}))
+	var expected = "<input type=\"number\" id=\"age\" name=\"age\" data-a=\"&#34;x&#34;\" max=\"10\" step=\"2\">\r\n"
+	if field.Field().String() != expected {
+		t.Errorf("Expected \n%q\ngot \n%q", expected, field.Field().String())
+	}
+}
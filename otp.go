@@ -0,0 +1,118 @@
+package forms
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// An OTPField asks for a numeric one-time code of a fixed length.
+//
+// By default it renders a single input, with Segmented set it renders one input per digit,
+// named name_0 to name_{Length-1}, which are joined into the value of the field during Fill.
+type OTPField struct {
+	BaseField
+	Length    int
+	Segmented bool
+}
+
+// NewOTPField creates a field for a one-time code of length digits.
+func NewOTPField(name string, length int) *OTPField {
+	var o = &OTPField{Length: length}
+	o.Name = name
+	o.Type = TypeText
+	o.LabelText = DefaultLabeler(name)
+	o.InputMode = "numeric"
+	o.Autocomplete = AutocompleteOneTimeCode
+	o.Pattern = "[0-9]{" + strconv.Itoa(length) + "}"
+	o.Attrs = map[string]string{"maxlength": strconv.Itoa(length)}
+	return o
+}
+
+// OTPField adds a field for a one-time code of length digits.
+func (f *Form) OTPField(name string, length int) *OTPField {
+	var field = NewOTPField(name, length)
+	f.AddFields(field)
+	return field
+}
+
+// Field renders a single input, or an input per digit inside of a div if Segmented is set.
+func (o *OTPField) Field() ElementInterface {
+	if !o.Segmented {
+		return o.BaseField.Field()
+	}
+	return Element(render(WidgetContext{Field: &o.BaseField, Value: o.Value().String()}, func(b *bytes.Buffer, f *Field, value string) {
+		var id = f.EffectiveID()
+		b.WriteString(`<div`)
+		writeAttr(b, "id", id)
+		if class := f.class(); class != "" {
+			writeAttr(b, "class", class)
+		}
+		b.WriteString(">")
+		b.WriteString(f.newline())
+		for i := 0; i < o.Length; i++ {
+			var index = strconv.Itoa(i)
+			b.WriteString(`<input type="text"`)
+			writeAttr(b, "id", id+"_"+index)
+			writeAttr(b, "name", f.Name+"_"+index)
+			if i < len(value) {
+				writeAttr(b, "value", value[i:i+1])
+			}
+			if i == 0 && f.Autocomplete != "" {
+				writeAttr(b, "autocomplete", f.Autocomplete)
+			}
+			if f.Disabled {
+				b.WriteString(` disabled`)
+			}
+			writeAttr(b, "inputmode", "numeric")
+			writeAttr(b, "maxlength", "1")
+			writeAttr(b, "pattern", "[0-9]")
+			if f.Required {
+				b.WriteString(` required`)
+			}
+			b.WriteString(">")
+			b.WriteString(f.newline())
+		}
+		b.WriteString("</div>")
+		b.WriteString(f.newline())
+	}))
+}
+
+// Validate runs the validation of the embedded field, and requires the value to be exactly Length digits.
+func (o *OTPField) Validate() error {
+	if err := o.BaseField.Validate(); err != nil {
+		return err
+	}
+	var value = o.Value().String()
+	if value == "" {
+		return nil
+	}
+	if len(value) != o.Length || strings.Trim(value, "0123456789") != "" {
+		return fmt.Errorf("%s must be %d digits", o.LabelText, o.Length)
+	}
+	return nil
+}
+
+// collect joins the submitted digits of a segmented field.
+//
+// If none of the segments were submitted, the whole code may be submitted under the field's name.
+func (o *OTPField) collect(values url.Values, caseSensitive bool) []string {
+	if !o.Segmented {
+		return lookup(values, o.Name, caseSensitive)
+	}
+	var b strings.Builder
+	var submitted bool
+	for i := 0; i < o.Length; i++ {
+		var v = lookup(values, o.Name+"_"+strconv.Itoa(i), caseSensitive)
+		if len(v) > 0 {
+			submitted = true
+			b.WriteString(strings.TrimSpace(v[0]))
+		}
+	}
+	if !submitted {
+		return lookup(values, o.Name, caseSensitive)
+	}
+	return []string{b.String()}
+}
@@ -0,0 +1,52 @@
+package forms_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestOTPField(t *testing.T) {
+	var f = forms.Form{}
+	var otp = f.OTPField("code", 3)
+	otp.Required = true
+	var expected = "<input type=\"text\" id=\"code\" name=\"code\" autocomplete=\"one-time-code\" inputmode=\"numeric\" maxlength=\"3\" pattern=\"[0-9]{3}\" required>\r\n"
+	if got := otp.Field().String(); got != expected {
+		t.Errorf("Expected \n%s\ngot \n%s", expected, got)
+	}
+
+	otp.Segmented = true
+	expected = "<div id=\"code\">\r\n" +
+		"<input type=\"text\" id=\"code_0\" name=\"code_0\" autocomplete=\"one-time-code\" inputmode=\"numeric\" maxlength=\"1\" pattern=\"[0-9]\" required>\r\n" +
+		"<input type=\"text\" id=\"code_1\" name=\"code_1\" inputmode=\"numeric\" maxlength=\"1\" pattern=\"[0-9]\" required>\r\n" +
+		"<input type=\"text\" id=\"code_2\" name=\"code_2\" inputmode=\"numeric\" maxlength=\"1\" pattern=\"[0-9]\" required>\r\n" +
+		"</div>\r\n"
+	if got := otp.Field().String(); got != expected {
+		t.Errorf("Expected \n%s\ngot \n%s", expected, got)
+	}
+
+	if !f.FillValues(map[string][]string{"code_0": {"4"}, "code_1": {"0"}, "code_2": {"7"}}) {
+		t.Fatalf("Expected the segments to be reassembled into a valid code, got %v", f.Errors)
+	}
+	var code string
+	if err := f.Scan(nil, &code); err != nil || code != "407" {
+		t.Errorf("Expected to scan the joined code, got %q (%v)", code, err)
+	}
+	if got := otp.Field().String(); !strings.Contains(got, `name="code_1" value="0"`) {
+		t.Errorf("Expected the segments to be rendered with their digits, got \n%s", got)
+	}
+
+	for _, values := range []map[string][]string{
+		{"code_0": {"4"}, "code_1": {"0"}},
+		{"code_0": {"4"}, "code_1": {"x"}, "code_2": {"7"}},
+		{"code": {"1234"}},
+	} {
+		if f.FillValues(values) {
+			t.Errorf("Expected %v to be rejected", values)
+		}
+	}
+	if !f.FillValues(map[string][]string{"code": {"123"}}) {
+		t.Errorf("Expected the whole code to be accepted under the field's name, got %v", f.Errors)
+	}
+}
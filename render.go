@@ -0,0 +1,36 @@
+package forms
+
+// RenderConfig configures the whitespace written between rendered elements.
+type RenderConfig struct {
+	// Written after each element, defaults to "\r\n" when empty.
+	LineEnding string
+	// Drop all whitespace between elements.
+	Compact bool
+}
+
+// DefaultRenderConfig is used by fields which do not belong to a form with its own RenderConfig,
+// and by FormErrors.
+var DefaultRenderConfig = RenderConfig{LineEnding: "\r\n"}
+
+func (c *RenderConfig) newline() string {
+	if c.Compact {
+		return ""
+	}
+	if c.LineEnding == "" {
+		return "\r\n"
+	}
+	return c.LineEnding
+}
+
+// renderConfig returns the form's RenderConfig, or DefaultRenderConfig.
+func (f *Form) renderConfig() *RenderConfig {
+	if f != nil && f.RenderConfig != nil {
+		return f.RenderConfig
+	}
+	return &DefaultRenderConfig
+}
+
+// newline returns the line ending written after each element of the field.
+func (f *Field) newline() string {
+	return f.form.renderConfig().newline()
+}
@@ -0,0 +1,399 @@
+package forms_test
+
+import (
+	"html/template"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+	"github.com/Nigel2392/forms/goldentest"
+	"github.com/Nigel2392/forms/validators"
+)
+
+func BenchmarkTextField(b *testing.B) {
+	var field = forms.New("name", forms.WithClass("form-control"), forms.WithPlaceholder("Your name"), forms.WithValue("John"), forms.WithRequired())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = field.Field()
+	}
+}
+
+func BenchmarkSelectField(b *testing.B) {
+	var options = make([]forms.Option, 100)
+	for i := range options {
+		options[i] = forms.Option{Text: "Option " + strconv.Itoa(i), Value: forms.NewValue(strconv.Itoa(i)), Selected: i == 50}
+	}
+	var field = forms.New("choice", forms.WithType(forms.TypeSelect), forms.WithOptions(options))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = field.Field()
+	}
+}
+
+func BenchmarkForm(b *testing.B) {
+	var f = benchmarkForm()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = f.AsP()
+	}
+}
+
+// BenchmarkScanNumbers scans a numeric form, which reuses the numbers parsed during validation.
+func BenchmarkScanNumbers(b *testing.B) {
+	var f = &forms.Form{}
+	var values = url.Values{}
+	var names = make([]string, 20)
+	var dst = make([]any, 20)
+	for i := range names {
+		names[i] = "number_" + strconv.Itoa(i)
+		f.AddNumberField(names[i])
+		values.Set(names[i], strconv.Itoa(i*1000)+".5")
+		dst[i] = new(float64)
+	}
+	f.FillValues(values)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := f.Scan(names, dst...); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkForm() *forms.Form {
+	var f = &forms.Form{}
+	for i := 0; i < 20; i++ {
+		var name = "field_" + strconv.Itoa(i)
+		switch i % 4 {
+		case 0:
+			f.TextField(name, name, "form-control", "Placeholder", "value")
+		case 1:
+			f.NumberField(name, name, "form-control", "", i)
+		case 2:
+			f.EmailField(name, name, "form-control", "", "john@example.com").Required = true
+		case 3:
+			f.TextAreaField(name, name, "form-control", "", "some text")
+		}
+	}
+	return f
+}
+
+func TestRenderGolden(t *testing.T) {
+	var options = []forms.Option{
+		{Text: "One", Value: forms.NewValue("1")},
+		{Text: "Two", Value: forms.NewValue("2"), Selected: true},
+	}
+	var f = forms.Form{}
+	f.SelectField("choice", "", "form-select", options)
+	f.TextAreaField("bio", "", "", "", "Hello")
+	f.CheckboxField("check", "", "", "", false).SetValue([]string{"on"})
+
+	var expected = "<p><label for=\"choice\">Choice</label>\r\n<select type=\"select\" id=\"choice\" name=\"choice\" class=\"form-select\">\r\n" +
+		"<option value=\"1\">One</option>\r\n<option value=\"2\" selected>Two</option>\r\n</select>\r\n</p>" +
+		"<p><label for=\"bio\">Bio</label>\r\n<textarea type=\"textarea\" id=\"bio\" name=\"bio\" value=\"Hello\">Hello</textarea>\r\n</p>" +
+		"<p><label for=\"check\">Check</label>\r\n<input type=\"checkbox\" id=\"check\" name=\"check\" value=\"on\" checked>\r\n</p>"
+	if string(f.AsP()) != expected {
+		t.Errorf("Expected \n%q\ngot \n%q", expected, f.AsP())
+	}
+}
+
+type benchmarkStruct struct {
+	Username  string  `form:"label:Username; placeholder:Username; required:true; regex:^[a-z]+$"`
+	Email     string  `form:"type:email; label:Email; required:true"`
+	FirstName string  `form:"label:First name; max:50"`
+	LastName  string  `form:"label:Last name; max:50"`
+	Age       int     `form:"label:Age; min:18; max:120"`
+	Height    float64 `form:"label:Height"`
+	Weight    float64 `form:"label:Weight"`
+	Street    string  `form:"label:Street; regex:^[A-Za-z ]+$"`
+	City      string  `form:"label:City"`
+	Zip       string  `form:"label:Zip; regex:^[0-9]{4}[A-Z]{2}$"`
+	Country   string  `form:"label:Country"`
+	Phone     string  `form:"label:Phone; regex:<<phone>>"`
+	Bio       string  `form:"type:textarea; label:Bio; max:500"`
+	Admin     bool    `form:"label:Admin"`
+	Active    bool    `form:"label:Active"`
+}
+
+func TestWidgets(t *testing.T) {
+	var field = forms.New("color", forms.WithType(forms.TypeSelect), forms.WithValue("green"), forms.WithOptions([]forms.Option{
+		{Value: forms.NewValue("red"), Text: "Red"},
+		{Value: forms.NewValue("green"), Text: "Green", Selected: true},
+	}))
+
+	var expected = "<select type=\"select\" id=\"color\" name=\"color\" value=\"green\">\r\n" +
+		"<option value=\"red\">Red</option>\r\n" +
+		"<option value=\"green\" selected>Green</option>\r\n" +
+		"</select>\r\n"
+	if got := field.Field().String(); got != expected {
+		t.Errorf("Expected \n%s\ngot \n%s", expected, got)
+	}
+
+	field.Widget = forms.RadioSelect{}
+	expected = "<div id=\"color\">\r\n" +
+		"<input type=\"radio\" id=\"color_0\" name=\"color\" value=\"red\"><label for=\"color_0\">Red</label>\r\n" +
+		"<input type=\"radio\" id=\"color_1\" name=\"color\" value=\"green\" checked><label for=\"color_1\">Green</label>\r\n" +
+		"</div>\r\n"
+	if got := field.Field().String(); got != expected {
+		t.Errorf("Expected \n%s\ngot \n%s", expected, got)
+	}
+
+	field.SetValue([]string{"red"})
+	field.Options[1].Selected = false
+	if got := field.Field().String(); !strings.Contains(got, `value="red" checked>`) || strings.Contains(got, `value="green" checked`) {
+		t.Errorf("Expected the submitted value to be checked, got \n%s", got)
+	}
+
+	field.Widget = forms.WidgetFunc(func(ctx forms.WidgetContext) template.HTML {
+		return template.HTML("<custom>" + ctx.Value + "</custom>")
+	})
+	if got := field.Field().String(); got != "<custom>red</custom>" {
+		t.Errorf("Expected the custom widget to be used, got %s", got)
+	}
+}
+
+func TestRenderConfig(t *testing.T) {
+	var f = benchmarkForm()
+	var standard = string(f.AsP())
+
+	f.RenderConfig = &forms.RenderConfig{LineEnding: "\n"}
+	var unix = string(f.AsP())
+	if strings.Contains(unix, "\r") || unix != strings.ReplaceAll(standard, "\r\n", "\n") {
+		t.Errorf("Expected only the line endings to change, got \n%s", unix)
+	}
+
+	f.RenderConfig = &forms.RenderConfig{Compact: true}
+	var compact = string(f.AsP())
+	if compact != strings.ReplaceAll(standard, "\r\n", "") {
+		t.Errorf("Expected all whitespace between elements to be dropped, got \n%s", compact)
+	}
+	if len(compact) >= len(unix) || len(unix) >= len(standard) {
+		t.Errorf("Expected compact < unix < standard output, got %d, %d and %d bytes", len(compact), len(unix), len(standard))
+	}
+	t.Logf("standard: %d bytes, unix: %d bytes, compact: %d bytes", len(standard), len(unix), len(compact))
+}
+
+func TestCanonicalAttributeOrder(t *testing.T) {
+	var attrs = map[string]string{
+		"value":       "1",
+		"data-z":      "z",
+		"name":        "n",
+		"aria-label":  `a "label"`,
+		"type":        "text",
+		"id":          "i",
+		"placeholder": "p",
+	}
+	var expected = ` type="text" id="i" name="n" value="1" aria-label="a &#34;label&#34;" data-z="z" disabled placeholder="p" required`
+	for i := 0; i < 10; i++ {
+		if got := forms.BuildAttrs(attrs, []string{"required", "disabled"}); got != expected {
+			t.Fatalf("Expected \n%s\ngot \n%s", expected, got)
+		}
+	}
+
+	var field = forms.New("q", forms.WithValue("go"), forms.WithClass("search"), forms.WithRequired(), forms.WithAttrs(map[string]string{
+		"data-b": "2", "data-a": "1", "aria-label": "Search", "hx-get": "/search",
+	}))
+	expected = "<input type=\"text\" id=\"q\" name=\"q\" value=\"go\" aria-label=\"Search\" class=\"search\" data-a=\"1\" data-b=\"2\" hx-get=\"/search\" required>\r\n"
+	for i := 0; i < 10; i++ {
+		if got := field.Field().String(); got != expected {
+			t.Fatalf("Expected \n%s\ngot \n%s", expected, got)
+		}
+	}
+}
+
+func BenchmarkGenerateFieldsFromStruct(b *testing.B) {
+	var s = benchmarkStruct{Username: "john", Age: 42, Height: 1.8}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := forms.GenerateFieldsFromStruct(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestClientHints(t *testing.T) {
+	var field = forms.New("zip", forms.WithValidators(
+		validators.MaxLength(6),
+		validators.Regex(`^[0-9]{4}[A-Z]{2}$`, false),
+	))
+	var expected = "<input type=\"text\" id=\"zip\" name=\"zip\" maxlength=\"6\" pattern=\"^[0-9]{4}[A-Z]{2}$\">\r\n"
+	if got := field.Field().String(); got != expected {
+		t.Errorf("Expected \n%s\ngot \n%s", expected, got)
+	}
+
+	field = forms.New("age", forms.WithType(forms.TypeNumber), forms.WithValidators(
+		validators.MinValue(0.5), validators.MaxValue(120),
+		validators.Regex(`[0-9]+`, false),
+	))
+	expected = "<input type=\"number\" id=\"age\" name=\"age\" max=\"120\" min=\"0.5\">\r\n"
+	if got := field.Field().String(); got != expected {
+		t.Errorf("Expected unanchored expressions to be skipped, got \n%s", got)
+	}
+
+	field.NoClientHints = true
+	expected = "<input type=\"number\" id=\"age\" name=\"age\">\r\n"
+	if got := field.Field().String(); got != expected {
+		t.Errorf("Expected no hints with NoClientHints, got \n%s", got)
+	}
+}
+
+func TestCheckValue(t *testing.T) {
+	var f = forms.Form{}
+	f.AddField("size", forms.WithType(forms.TypeRadio), forms.WithID("size_s"), forms.WithCheckValue("s"), forms.WithLabel(""))
+	f.AddField("size", forms.WithType(forms.TypeRadio), forms.WithID("size_m"), forms.WithCheckValue("m"), forms.WithLabel(""))
+	f.AddCheckboxField("terms", forms.WithLabel(""))
+	f.AddCheckboxField("newsletter", forms.WithCheckValue("yes"), forms.WithLabel(""))
+
+	var expected = "<p><input type=\"radio\" id=\"size_s\" name=\"size\" value=\"s\">\r\n</p>" +
+		"<p><input type=\"radio\" id=\"size_m\" name=\"size\" value=\"m\">\r\n</p>" +
+		"<p><input type=\"checkbox\" id=\"terms\" name=\"terms\" value=\"on\">\r\n</p>" +
+		"<p><input type=\"checkbox\" id=\"newsletter\" name=\"newsletter\" value=\"yes\">\r\n</p>"
+	if got := string(f.AsP()); got != expected {
+		t.Errorf("Expected \n%q\ngot \n%q", expected, got)
+	}
+
+	f.FillValues(map[string][]string{"size": {"m"}, "terms": {"on"}, "newsletter": {"on"}})
+	expected = "<p><input type=\"radio\" id=\"size_s\" name=\"size\" value=\"s\">\r\n</p>" +
+		"<p><input type=\"radio\" id=\"size_m\" name=\"size\" value=\"m\" checked>\r\n</p>" +
+		"<p><input type=\"checkbox\" id=\"terms\" name=\"terms\" value=\"on\" checked>\r\n</p>" +
+		"<p><input type=\"checkbox\" id=\"newsletter\" name=\"newsletter\" value=\"yes\">\r\n</p>"
+	if got := string(f.AsP()); got != expected {
+		t.Errorf("Expected \n%q\ngot \n%q", expected, got)
+	}
+
+	var small, medium, terms, newsletter bool
+	if err := f.Scan(nil, &small, &medium, &terms, &newsletter); err != nil {
+		t.Fatal(err)
+	}
+	if small || !medium || !terms || newsletter {
+		t.Errorf("Expected only the submitted values to be checked, got %v %v %v %v", small, medium, terms, newsletter)
+	}
+}
+
+func TestRenderString(t *testing.T) {
+	var f = &forms.Form{RenderConfig: &forms.RenderConfig{Compact: true}}
+	f.AddTextField("name", forms.WithPlaceholder("Your  name"), forms.WithClass("input"))
+	var got, err = f.RenderString("p")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected = "<p><label for=\"name\">Name</label><input class=\"input\" id=\"name\" name=\"name\" placeholder=\"Your name\" type=\"text\"></p>\n"
+	if got != expected {
+		t.Errorf("Expected sorted attributes and collapsed whitespace, got %q", got)
+	}
+	if _, err := f.RenderString("table"); err == nil {
+		t.Error("Expected an error for an unknown renderer")
+	}
+}
+
+func goldenForm() *forms.Form {
+	var f = &forms.Form{}
+	f.CSRFToken("token")
+	f.AddTextField("name", forms.WithRequired(), forms.WithPlaceholder("Your name"))
+	f.AddEmailField("email", forms.WithRequired())
+	f.AddPasswordField("password")
+	f.AddTextAreaField("bio")
+	f.AddSelectField("color", forms.WithOptions([]forms.Option{
+		{Text: "Red", Value: forms.NewValue("red")},
+		{Text: "Green", Value: forms.NewValue("green")},
+	}))
+	f.AddCheckboxField("terms", forms.WithLabel("I accept the terms"))
+	f.AddFileField("avatar")
+	f.AddFieldSet(&forms.FieldSet{Legend: "Account", Fields: []string{"name", "email", "password"}})
+	return f
+}
+
+func TestGolden(t *testing.T) {
+	var f = goldenForm()
+	goldentest.AssertForm(t, f, "p", "form_p")
+	goldentest.AssertForm(t, f, "hidden", "form_hidden")
+
+	f = goldenForm()
+	f.ShowErrorSummary = true
+	f.FillValues(url.Values{"password": {"hunter2"}})
+	goldentest.AssertForm(t, f, "p", "form_p_errors")
+	goldentest.AssertForm(t, f, "errors", "form_errors")
+}
+
+func labelPlacementForm() *forms.Form {
+	var f = &forms.Form{}
+	f.AddTextField("name")
+	f.AddCheckboxField("terms", forms.WithLabel("I accept the terms"))
+	f.AddField("plan", forms.WithType(forms.TypeRadio), forms.WithID("plan-basic"), forms.WithCheckValue("basic"), forms.WithLabel("Basic"))
+	return f
+}
+
+func TestLabelPlacement(t *testing.T) {
+	var f = labelPlacementForm()
+	goldentest.AssertForm(t, f, "p", "form_label_before")
+
+	f = labelPlacementForm()
+	f.LabelAfter = true
+	goldentest.AssertForm(t, f, "p", "form_label_after")
+
+	// Bootstrap's form-check markup places the label after the input.
+	f = labelPlacementForm()
+	f.Field("terms").(*forms.Field).WrapperClass = "form-check"
+	var html = string(f.AsP())
+	if !strings.Contains(html, `<p class="form-check"><input type="checkbox" id="terms" name="terms" value="on">`) ||
+		!strings.Contains(html, `<p><label for="plan-basic">Basic</label>`) {
+		t.Errorf("Expected only the form-check field to render its label after the input, got %s", html)
+	}
+
+	var field = forms.New("remember", forms.WithType(forms.TypeCheck))
+	field.LabelAfter = true
+	if got := field.String(); !strings.HasPrefix(got, `<input type="checkbox" id="remember"`) || !strings.Contains(got, `<label for="remember">Remember</label>`) {
+		t.Errorf("Expected the input before a label for the same ID, got %s", got)
+	}
+	field = forms.New("email", forms.WithType(forms.TypeEmail))
+	field.LabelAfter = true
+	if got := field.String(); !strings.HasPrefix(got, "<label") {
+		t.Errorf("Expected LabelAfter to be ignored for other types, got %s", got)
+	}
+}
+
+func TestRenderEscapesValues(t *testing.T) {
+	const payload = `"><script>x</script>`
+	var f = &forms.Form{}
+	var name = f.AddTextField("name")
+	var bio = f.AddTextAreaField("bio")
+	var color = f.AddSelectField("color", forms.WithEmptyLabel("<i>choose</i>"), forms.WithOptions([]forms.Option{
+		{Text: "<b>Red</b>", Value: forms.NewValue(payload)},
+	}))
+	var size = f.AddField("size", forms.WithOptions([]forms.Option{{Text: "<b>Big</b>", Value: forms.NewValue(payload)}}))
+	size.Widget = forms.RadioSelect{}
+	f.FillValues(url.Values{"name": {payload}, "bio": {"</textarea>" + payload}})
+
+	for _, field := range []*forms.Field{name, bio, color, size} {
+		var html = field.Field().String()
+		if strings.Contains(html, "<script>") || strings.Contains(html, "<b>") || strings.Contains(html, "<i>") || strings.Contains(html, "</textarea><") {
+			t.Errorf("Expected the values of %s to be escaped, got %s", field.Name, html)
+		}
+	}
+	if html := name.Field().String(); !strings.Contains(html, `value="&#34;&gt;&lt;script&gt;x&lt;/script&gt;"`) {
+		t.Errorf("Expected the escaped value attribute, got %s", html)
+	}
+}
+
+func TestPrefixedIDs(t *testing.T) {
+	var f = &forms.Form{Prefix: "billing", AutoIDFormat: "id_%s"}
+	f.AddTextField("name")
+	f.AddEmailField("email", forms.WithID("contact-email"))
+	if id := f.Field("name").(*forms.Field).EffectiveID(); id != "billing-id_name" {
+		t.Errorf("Expected the effective ID to be billing-id_name, got %s", id)
+	}
+	var html = string(f.AsP())
+	f.Prefix = "shipping"
+	html += string(f.AsP())
+
+	for _, id := range []string{"billing-id_name", "billing-contact-email", "shipping-id_name", "shipping-contact-email"} {
+		if strings.Count(html, `id="`+id+`"`) != 1 || strings.Count(html, `for="`+id+`"`) != 1 {
+			t.Errorf("Expected a single input and label for %s, got %s", id, html)
+		}
+	}
+	if !strings.Contains(html, `name="name"`) {
+		t.Errorf("Expected the names to be left untouched, got %s", html)
+	}
+}
@@ -0,0 +1,106 @@
+package forms
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// RequestInfo describes the request a form was filled from.
+//
+// Fill stores it in the context passed to ContextValidators, see RequestInfoFrom.
+type RequestInfo struct {
+	// The client IP, as returned by Form.ClientIP or RemoteIP.
+	RemoteIP  string
+	UserAgent string
+	Header    http.Header
+}
+
+// Get returns the first value of the request header with the given name.
+func (i RequestInfo) Get(name string) string {
+	return i.Header.Get(name)
+}
+
+// A ContextValidator validates a field using the context of the validation,
+// e.g. to rate limit submissions by the client's IP with RequestInfoFrom.
+//
+// Context validators run during Form.Validate, after the field's FieldValidators.
+type ContextValidator func(ctx context.Context, field FormElement) error
+
+type requestInfoKey struct{}
+
+// WithRequestInfo returns a copy of ctx carrying info,
+// for validating with ValidateCtx or FillCtx outside of a request.
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFrom returns the RequestInfo stored in ctx by Fill, if any.
+func RequestInfoFrom(ctx context.Context) (RequestInfo, bool) {
+	var info, ok = ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info, ok
+}
+
+// requestInfo describes r, using the form's ClientIP for the client IP.
+func (f *Form) requestInfo(r *http.Request) RequestInfo {
+	return RequestInfo{
+		RemoteIP:  f.clientIP(r),
+		UserAgent: r.UserAgent(),
+		Header:    r.Header,
+	}
+}
+
+func (f *Form) clientIP(r *http.Request) string {
+	if f.ClientIP != nil {
+		return f.ClientIP(r)
+	}
+	return RemoteIP(r)
+}
+
+// ForwardedFor returns a Form.ClientIP function which trusts the X-Forwarded-For header
+// of requests coming from the given proxies, IP addresses or CIDR prefixes such as "10.0.0.0/8".
+//
+// The client IP is the last address in the header which is not a trusted proxy.
+// Requests from other addresses, and invalid proxies, fall back to RemoteIP.
+func ForwardedFor(proxies ...string) func(r *http.Request) string {
+	var trusted = make([]netip.Prefix, 0, len(proxies))
+	for _, p := range proxies {
+		if prefix, err := netip.ParsePrefix(p); err == nil {
+			trusted = append(trusted, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(p); err == nil {
+			trusted = append(trusted, netip.PrefixFrom(addr, addr.BitLen()))
+		}
+	}
+	var isTrusted = func(ip string) bool {
+		var addr, err = netip.ParseAddr(ip)
+		if err != nil {
+			return false
+		}
+		addr = addr.Unmap()
+		for _, prefix := range trusted {
+			if prefix.Contains(addr) {
+				return true
+			}
+		}
+		return false
+	}
+	return func(r *http.Request) string {
+		var ip = RemoteIP(r)
+		if !isTrusted(ip) {
+			return ip
+		}
+		var hops = strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			var hop = strings.TrimSpace(hops[i])
+			if hop == "" {
+				continue
+			}
+			if !isTrusted(hop) {
+				return hop
+			}
+			ip = hop
+		}
+		return ip
+	}
+}
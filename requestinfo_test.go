@@ -0,0 +1,80 @@
+package forms_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+	"github.com/Nigel2392/forms/validators"
+)
+
+func newForwardedRequest(userAgent string) *http.Request {
+	var r = newPostRequest(url.Values{"comment": {"Hello"}}).Request
+	r.RemoteAddr = "10.0.0.1:1234"
+	r.Header.Set("User-Agent", userAgent)
+	r.Header.Add("X-Forwarded-For", "198.51.100.7, 203.0.113.9")
+	r.Header.Add("X-Forwarded-For", "10.0.0.2")
+	return r
+}
+
+func TestRequestInfo(t *testing.T) {
+	// The context validator records the rate limit keys of the requests in keys.
+	var keys []string
+	var f = &forms.Form{}
+	var field = f.AddTextField("comment")
+	field.ContextValidators = []forms.ContextValidator{
+		func(ctx context.Context, field forms.FormElement) error {
+			var info, ok = forms.RequestInfoFrom(ctx)
+			if !ok {
+				return nil
+			}
+			keys = append(keys, validators.RateLimitKey(info.RemoteIP, field.GetName()))
+			if strings.Contains(info.UserAgent, "bot") || info.Get("X-Spam") != "" {
+				return errors.New("spam")
+			}
+			return nil
+		},
+	}
+
+	if !f.FillRequest(newForwardedRequest("Mozilla/5.0")) {
+		t.Fatalf("Expected the form to be valid, got %v", f.Errors)
+	}
+	if len(keys) != 1 || keys[0] != "comment@10.0.0.1" {
+		t.Errorf("Expected forwarding headers to be ignored by default, got %v", keys)
+	}
+
+	keys = nil
+	f.ClientIP = forms.ForwardedFor("10.0.0.0/8")
+	if f.FillRequest(newForwardedRequest("spambot")) {
+		t.Error("Expected the context validator to reject the user agent")
+	}
+	if len(keys) != 1 || keys[0] != "comment@203.0.113.9" {
+		t.Errorf("Expected the first untrusted forwarded IP, got %v", keys)
+	}
+
+	keys = nil
+	f.ClientIP = forms.ForwardedFor("192.0.2.1")
+	f.FillRequest(newForwardedRequest("Mozilla/5.0"))
+	if len(keys) != 1 || keys[0] != "comment@10.0.0.1" {
+		t.Errorf("Expected forwarding headers from untrusted proxies to be ignored, got %v", keys)
+	}
+
+	keys = nil
+	if !f.FillValues(url.Values{"comment": {"Hello"}}) || len(keys) != 0 {
+		t.Errorf("Expected no request info outside of a request, got %v", keys)
+	}
+	var ctx = forms.WithRequestInfo(context.Background(), forms.RequestInfo{
+		RemoteIP: "192.0.2.5",
+		Header:   http.Header{"X-Spam": {"1"}},
+	})
+	if f.ValidateCtx(ctx) {
+		t.Error("Expected ValidateCtx to pass the request info to the validators")
+	}
+	if len(keys) != 1 || keys[0] != "comment@192.0.2.5" {
+		t.Errorf("Expected the request info of ValidateCtx, got %v", keys)
+	}
+}
@@ -0,0 +1,170 @@
+package forms
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/Nigel2392/forms/validators"
+)
+
+// Rules describes the constraints of the field, so client-side validation can mirror them.
+//
+// Rules are derived from the field's settings, followed by the rules of its
+// validators which can describe themselves, see validators.Describe.
+func (f *Field) Rules() []validators.Rule {
+	var rules = make([]validators.Rule, 0)
+	var add = func(code string, params map[string]any) {
+		rules = append(rules, validators.Rule{Code: code, Params: params})
+	}
+	if f.Required {
+		add("required", nil)
+	}
+	switch f.Type {
+	case TypeNumber, TypeRange:
+		if f.HasMin() {
+			add("min", map[string]any{"min": f.Min})
+		}
+		if f.HasMax() {
+			add("max", map[string]any{"max": f.Max})
+		}
+	case TypeFile:
+	default:
+		if f.HasMin() {
+			add("min_length", map[string]any{"min": f.Min})
+		}
+		if f.HasMax() {
+			add("max_length", map[string]any{"max": f.Max})
+		}
+	}
+	if f.Type == TypeEmail {
+		add("email", nil)
+	}
+	if f.Pattern != "" {
+		add("regex", map[string]any{"pattern": f.Pattern})
+	}
+	if f.MinSelections > 0 {
+		add("min_selections", map[string]any{"min": f.MinSelections})
+	}
+	if f.MaxSelections > 0 {
+		add("max_selections", map[string]any{"max": f.MaxSelections})
+	}
+	for _, v := range f.Validators {
+		if rule, ok := validators.Describe(v); ok {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// clientHints adds the HTML5 validation attributes matching the rules of the field's validators,
+// e.g. maxlength for validators.MaxLength, unless NoClientHints is set.
+//
+// Attributes already set by the field itself, or through Attrs, are not overridden.
+func (f *Field) clientHints(add func(name, value string)) {
+	if f.NoClientHints || len(f.Validators) == 0 {
+		return
+	}
+	var set = func(name, value string) {
+		if _, ok := f.Attrs[name]; !ok {
+			add(name, value)
+		}
+	}
+	for _, v := range f.Validators {
+		var rule, ok = validators.Describe(v)
+		if !ok {
+			continue
+		}
+		switch rule.Code {
+		case "max_length":
+			set("maxlength", ruleParam(rule.Params["max"]))
+		case "min_length":
+			set("minlength", ruleParam(rule.Params["min"]))
+		case "length":
+			set("minlength", ruleParam(rule.Params["min"]))
+			set("maxlength", ruleParam(rule.Params["max"]))
+		case "min":
+			if !f.HasMin() {
+				set("min", ruleParam(rule.Params["min"]))
+			}
+		case "max":
+			if !f.HasMax() {
+				set("max", ruleParam(rule.Params["max"]))
+			}
+		case "regex":
+			var pattern, _ = rule.Params["pattern"].(string)
+			if f.Pattern == "" && htmlPattern(pattern) {
+				set("pattern", pattern)
+			}
+		}
+	}
+}
+
+func ruleParam(v any) string {
+	switch v := v.(type) {
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	}
+	return ""
+}
+
+// htmlPattern reports whether a Go regular expression can be used as an HTML pattern attribute.
+//
+// The pattern attribute always matches the whole value, so only expressions anchored at both ends qualify.
+// Syntax which JavaScript does not understand, such as flags, \A, \z, \Q...\E and POSIX classes, is rejected.
+func htmlPattern(pattern string) bool {
+	if len(pattern) < 2 || pattern[0] != '^' || pattern[len(pattern)-1] != '$' || strings.HasSuffix(pattern, `\$`) {
+		return false
+	}
+	for _, unsupported := range []string{"(?P<", "(?i", "(?m", "(?s", "(?U", "(?-", `\A`, `\z`, `\Q`, `\C`, "[[:"} {
+		if strings.Contains(pattern, unsupported) {
+			return false
+		}
+	}
+	return true
+}
+
+type fieldJSON struct {
+	Name     string            `json:"name"`
+	Type     string            `json:"type"`
+	Label    string            `json:"label,omitempty"`
+	Required bool              `json:"required,omitempty"`
+	Values   []string          `json:"values,omitempty"`
+	Rules    []validators.Rule `json:"rules,omitempty"`
+}
+
+type formJSON struct {
+	Fields []fieldJSON `json:"fields"`
+	Errors FormErrors  `json:"errors,omitempty"`
+}
+
+// MarshalJSON encodes the fields of the form with their values and rules, and the errors of the form.
+//
+// Values of file fields and of sensitive fields, such as passwords, are not included.
+func (f *Form) MarshalJSON() ([]byte, error) {
+	if f == nil {
+		return []byte("null"), nil
+	}
+	var v = formJSON{
+		Fields: make([]fieldJSON, 0, len(f.Fields)),
+		Errors: f.Errors,
+	}
+	for _, field := range f.Fields {
+		var fj = fieldJSON{Name: field.GetName()}
+		if !field.IsFile() && !isSensitive(field) {
+			fj.Values = field.GetValue()
+		}
+		if fld, ok := asField(field); ok {
+			fj.Type = fld.Type
+			fj.Label = fld.LabelText
+			fj.Required = fld.Required
+			fj.Rules = fld.Rules()
+		}
+		v.Fields = append(v.Fields, fj)
+	}
+	return json.Marshal(v)
+}
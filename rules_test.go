@@ -0,0 +1,75 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+	"github.com/Nigel2392/forms/validators"
+)
+
+type slugValidator struct{}
+
+func (slugValidator) Validate(validators.FormValue) error { return nil }
+
+func (slugValidator) Describe() validators.Rule { return validators.Rule{Code: "slug"} }
+
+func TestValidatorRules(t *testing.T) {
+	if rule, ok := validators.Describe(validators.MaxLength(10)); !ok || rule.Code != "max_length" || rule.Params["max"] != 10 {
+		t.Errorf("Expected MaxLength to describe itself, got %+v", rule)
+	}
+	if _, ok := validators.Describe(validators.Email); ok {
+		t.Errorf("Expected plain functions not to be described")
+	}
+	if err := validators.MaxLength(3)(forms.NewValue("abcd")); err == nil {
+		t.Errorf("Expected adapted validators to keep validating")
+	}
+	if rule, ok := validators.Describe(validators.Func(slugValidator{})); !ok || rule.Code != "slug" {
+		t.Errorf("Expected custom checks implementing Described to describe themselves, got %+v", rule)
+	}
+	var first = func(v validators.FormValue) error { return validators.OneOf("a")(forms.NewValue(v.Value()[0])) }
+	if _, ok := validators.Describe(first); ok {
+		t.Errorf("Expected a validator panicking on an empty value not to be described")
+	}
+
+	var f = forms.Form{}
+	f.AddTextField("username", forms.WithRequired(), forms.WithMin(3), forms.WithValidators(
+		validators.Regex("^[a-z]+$", false),
+		func(validators.FormValue) error { return nil },
+	))
+	var rules = f.Field("username").(*forms.Field).Rules()
+	var codes = make([]string, 0, len(rules))
+	for _, r := range rules {
+		codes = append(codes, r.Code)
+	}
+	if strings.Join(codes, ",") != "required,min_length,regex" {
+		t.Errorf("Expected the field's rules in order, got %v", codes)
+	}
+
+	data, err := json.Marshal(&f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected = `{"fields":[{"name":"username","type":"text","label":"Username","required":true,` +
+		`"rules":[{"code":"required"},{"code":"min_length","params":{"min":3}},{"code":"regex","params":{"pattern":"^[a-z]+$"}}]}]}`
+	if string(data) != expected {
+		t.Errorf("Expected \n%s\ngot \n%s", expected, data)
+	}
+}
+
+func TestMarshalJSONSensitive(t *testing.T) {
+	var f = forms.Form{}
+	f.AddTextField("username")
+	f.AddPasswordField("pw")
+	f.FillValues(url.Values{"username": {"john"}, "pw": {"hunter2"}})
+
+	data, err := json.Marshal(&f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "hunter2") || !strings.Contains(string(data), `"values":["john"]`) {
+		t.Errorf("Expected the values of sensitive fields to be left out, got %s", data)
+	}
+}
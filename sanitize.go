@@ -0,0 +1,89 @@
+package forms
+
+import "strings"
+
+// A Sanitizer cleans a submitted value during Fill, see Field.Sanitizer.
+//
+// Any func(string) string can be used, such as the Sanitize method of a bluemonday policy:
+//
+//	field.Sanitizer = bluemonday.UGCPolicy().Sanitize
+type Sanitizer = func(string) string
+
+// StripTags is a Sanitizer removing all HTML tags and comments from the value, keeping their text.
+// The contents of script and style elements are removed as well.
+//
+// Tags are removed until none are left, as removing one tag can join the text around it into a new one,
+// e.g. "<<b>img>". Any remaining < and > are escaped.
+func StripTags(s string) string {
+	for {
+		var stripped = stripTags(s)
+		if stripped == s {
+			break
+		}
+		s = stripped
+	}
+	return tagEscaper.Replace(s)
+}
+
+var tagEscaper = strings.NewReplacer("<", "&lt;", ">", "&gt;")
+
+func stripTags(s string) string {
+	var b strings.Builder
+	var skipUntil string
+	for len(s) > 0 {
+		var i = strings.IndexByte(s, '<')
+		if i < 0 {
+			if skipUntil == "" {
+				b.WriteString(s)
+			}
+			break
+		}
+		if skipUntil == "" {
+			b.WriteString(s[:i])
+		}
+		s = s[i:]
+		if len(s) < 2 || !isTagStart(s[1]) {
+			if skipUntil == "" {
+				b.WriteByte('<')
+			}
+			s = s[1:]
+			continue
+		}
+		if strings.HasPrefix(s, "<!--") {
+			var end = strings.Index(s, "-->")
+			if end < 0 {
+				break
+			}
+			s = s[end+3:]
+			continue
+		}
+		var end = tagEnd(s)
+		if end < 0 {
+			break
+		}
+		var name = strings.ToLower(tagName(s[:end+1]))
+		switch {
+		case skipUntil != "":
+			if name == "/"+skipUntil {
+				skipUntil = ""
+			}
+		case name == "script" || name == "style":
+			skipUntil = name
+		}
+		s = s[end+1:]
+	}
+	return b.String()
+}
+
+func isTagStart(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '/' || c == '!' || c == '?'
+}
+
+// tagName returns the name of the tag, prefixed with a slash for closing tags.
+func tagName(tag string) string {
+	var name = strings.TrimSuffix(strings.TrimPrefix(tag, "<"), ">")
+	if i := strings.IndexAny(name, " \t\r\n/>"); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
@@ -0,0 +1,43 @@
+package forms_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestSanitizer(t *testing.T) {
+	for input, expected := range map[string]string{
+		"<p>Hello <b>world</b></p>":                 "Hello world",
+		"a < b and <!-- note -->c":                  "a &lt; b and c",
+		"<<b>img src=x onerror=alert(1)>":           "",
+		"<scr<b></b>ipt>alert(1)</script>":          "ipt&gt;alert(1)",
+		"<<<b>b>b>script>alert(1)":                  "script&gt;alert(1)",
+		`<img src="x" onerror="alert('>')">safe`:    "safe",
+		"before<script>alert('<b>')</script>after":  "beforeafter",
+		"<STYLE>p { color: red }</STYLE>plain text": "plain text",
+	} {
+		if got := forms.StripTags(input); got != expected {
+			t.Errorf("Expected StripTags(%q) to be %q, got %q", input, expected, got)
+		}
+	}
+
+	var f = &forms.Form{}
+	var bio = f.AddTextAreaField("bio", forms.WithSanitizer(forms.StripTags))
+	var raw = `<p onclick="steal()">Hi <script>alert(1)</script>there</p>`
+	if !f.FillValues(url.Values{"bio": {raw}}) {
+		t.Fatalf("Expected the form to be valid, got %v", f.Errors)
+	}
+	if len(bio.RawSubmitted) != 1 || bio.RawSubmitted[0] != raw {
+		t.Errorf("Expected the raw value to be retained, got %v", bio.RawSubmitted)
+	}
+	var scanned string
+	if err := f.Scan([]string{"bio"}, &scanned); err != nil || scanned != "Hi there" {
+		t.Errorf("Expected the sanitized value to be scanned, got %q (%v)", scanned, err)
+	}
+	if html := bio.Field().String(); !strings.Contains(html, ">Hi there</textarea>") || strings.Contains(html, "script") {
+		t.Errorf("Expected the sanitized value to be rendered, got %s", html)
+	}
+}
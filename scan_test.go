@@ -0,0 +1,315 @@
+package forms_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nigel2392/forms"
+	"github.com/Nigel2392/router/v3/request"
+)
+
+func TestScanErrors(t *testing.T) {
+	var f = &forms.Form{}
+	f.TextField("email", "", "", "", "")
+	f.NumberField("age", "", "", "", 0)
+	f.FillValues(url.Values{"email": {"john@example.com"}, "age": {"42"}})
+
+	var email string
+	var age int
+	if err := f.Scan([]string{"emial", "age"}, &email, &age); err == nil || err.Error() != "no field named 'emial'" {
+		t.Errorf("Expected the typo'd field name to be reported, got %v", err)
+	}
+	if err := f.Scan([]string{"email", "age"}, &email); err == nil || !strings.Contains(err.Error(), "2 field names but 1 destinations") {
+		t.Errorf("Expected the counts to be reported, got %v", err)
+	}
+	if err := f.Scan([]string{"*"}, &email); err == nil || !strings.Contains(err.Error(), "2 fields but 1 destinations") {
+		t.Errorf("Expected the counts to be reported, got %v", err)
+	}
+
+	if err := f.ScanMap(map[string]any{"age": &age, "email": &email}); err != nil || email != "john@example.com" || age != 42 {
+		t.Errorf("Expected the map to be scanned, got %q and %d (%v)", email, age, err)
+	}
+	if err := f.ScanMap(map[string]any{"emial": &email}); err == nil || err.Error() != "no field named 'emial'" {
+		t.Errorf("Expected the typo'd key to be reported, got %v", err)
+	}
+}
+
+func TestTypedValues(t *testing.T) {
+	var f = &forms.Form{}
+	f.AddNumberField("count")
+	f.AddNumberField("big")
+	f.AddNumberField("ratio")
+	f.DateField("start", forms.TypeDate)
+	f.DurationField("break")
+	if !f.FillValues(url.Values{"count": {"42.0"}, "big": {"9007199254740993"}, "ratio": {"0.25"}, "start": {"2024-03-01"}, "break": {"90m"}}) {
+		t.Fatalf("Expected the form to be valid, got %v", f.Errors)
+	}
+	if typed, ok := f.Get("ratio").Typed.(float64); !ok || typed != 0.25 {
+		t.Errorf("Expected the parsed number to be stored, got %v", f.Get("ratio").Typed)
+	}
+	if typed, ok := f.Get("start").Typed.(time.Time); !ok || !typed.Equal(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the parsed date to be stored, got %v", f.Get("start").Typed)
+	}
+
+	var count int
+	var big int64
+	var ratio float32
+	var start time.Time
+	var pause time.Duration
+	if err := f.Scan([]string{"count", "big", "ratio", "start", "break"}, &count, &big, &ratio, &start, &pause); err != nil {
+		t.Fatal(err)
+	}
+	if count != 42 || big != 9007199254740993 || ratio != 0.25 || start.Format("2006-01-02") != "2024-03-01" || pause != 90*time.Minute {
+		t.Errorf("Expected 42, 9007199254740993, 0.25, 2024-03-01 and 1h30m, got %d, %d, %v, %v and %v", count, big, ratio, start, pause)
+	}
+	if !strings.Contains(f.Field("count").(*forms.Field).Field().String(), `value="42.0"`) {
+		t.Errorf("Expected the submitted string to be rendered")
+	}
+}
+
+type UserID int64
+
+// ScanStr accepts both plain ids and prefixed ids such as "u42".
+func (id *UserID) ScanStr(s string) error {
+	var i, err = strconv.ParseInt(strings.TrimPrefix(s, "u"), 10, 64)
+	*id = UserID(i)
+	return err
+}
+
+func TestScanSliceTypes(t *testing.T) {
+	var f = &forms.Form{}
+	f.AddFields(
+		forms.New("ids", forms.WithType(forms.TypeSelect), forms.WithMultiple()),
+		forms.New("point", forms.WithType(forms.TypeSelect), forms.WithMultiple()),
+		forms.New("users", forms.WithType(forms.TypeSelect), forms.WithMultiple()),
+	)
+	f.FillValues(url.Values{"ids": {"1", "2", "3"}, "point": {"1.5", "-2"}, "users": {"u7", "8"}})
+
+	var ids []int
+	var point [2]float64
+	var users []UserID
+	if err := f.Scan([]string{"ids", "point", "users"}, &ids, &point, &users); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(ids) != "[1 2 3]" || point != [2]float64{1.5, -2} || fmt.Sprint(users) != "[7 8]" {
+		t.Errorf("Expected [1 2 3], [1.5 -2] and [7 8], got %v, %v and %v", ids, point, users)
+	}
+
+	var small [2]int
+	if err := f.Scan([]string{"ids"}, &small); err == nil || !strings.Contains(err.Error(), "array of length 2") {
+		t.Errorf("Expected too many values for the array to fail, got %v", err)
+	}
+	var tiny []int8
+	f.FillValues(url.Values{"ids": {"1", "300"}})
+	if err := f.Scan([]string{"ids"}, &tiny); err == nil {
+		t.Errorf("Expected 300 to overflow an int8, got %v", tiny)
+	}
+}
+
+func TestUnsupportedStructFieldTypes(t *testing.T) {
+	type Account struct {
+		ID    UserID `form:"label:ID"`
+		Owner *int   `form:"label:Owner"`
+	}
+	fields, err := forms.GenerateFieldsFromStruct(Account{ID: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields[0].Type != forms.TypeNumber || fields[0].Value().String() != "42" {
+		t.Errorf("Expected a number field with value 42, got %q with %q", fields[0].Type, fields[0].Value().String())
+	}
+
+	type Nested struct {
+		Meta map[string]string `form:"label:Meta"`
+	}
+	_, err = forms.GenerateFieldsFromStruct(Nested{Meta: map[string]string{}})
+	if err == nil || !strings.Contains(err.Error(), "Meta") || !strings.Contains(err.Error(), "map[string]string") {
+		t.Errorf("Expected an error naming the field and its type, got %v", err)
+	}
+}
+
+type Level int
+
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte([]string{"low", "high"}[l]), nil
+}
+
+func (l *Level) UnmarshalText(b []byte) error {
+	switch string(b) {
+	case "low":
+		*l = 0
+	case "high":
+		*l = 1
+	default:
+		return errors.New("unknown level")
+	}
+	return nil
+}
+
+type Color string
+
+func (c Color) StringValue() string { return "#" + string(c) }
+
+func (c Color) MarshalText() ([]byte, error) { return []byte(c), nil }
+
+type Weekday int
+
+func (d Weekday) String() string { return []string{"sunday", "monday"}[d] }
+
+func TestTextMarshalerPrecedence(t *testing.T) {
+	type Settings struct {
+		Level Level   `form:"label:Level"`
+		Color Color   `form:"label:Color"`
+		Day   Weekday `form:"label:Day"`
+		Count UserID  `form:"label:Count"`
+	}
+	fields, err := forms.GenerateFieldsFromStruct(Settings{Level: 1, Color: "fff", Day: 1, Count: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected = []string{"high", "#fff", "monday", "3"}
+	for i, field := range fields {
+		if field.Value().String() != expected[i] {
+			t.Errorf("Expected %s to be %q, got %q", field.Name, expected[i], field.Value().String())
+		}
+	}
+	if fields[0].Type != forms.TypeText {
+		t.Errorf("Expected text marshalers to be rendered as text, got %q", fields[0].Type)
+	}
+
+	var f = forms.Form{}
+	for _, field := range fields {
+		f.AddFields(field)
+	}
+	f.FillValues(url.Values{"Level": {"low"}, "Color": {"000"}, "Day": {"0"}, "Count": {"5"}})
+	var s = Settings{Level: 1}
+	if err := f.ScanStruct(&s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Level != 0 || s.Color != "000" || s.Day != 0 || s.Count != 5 {
+		t.Errorf("Expected values to be scanned back, got %+v", s)
+	}
+
+	f.FillValues(url.Values{"Level": {"medium"}})
+	if err := f.ScanStruct(&s); err == nil {
+		t.Errorf("Expected UnmarshalText errors to be returned")
+	}
+}
+
+type Status string
+
+const (
+	StatusDraft     Status = "draft"
+	StatusPublished Status = "published"
+)
+
+func (s Status) Choices() []forms.Option {
+	return []forms.Option{
+		{Value: forms.NewValue(string(StatusDraft)), Text: "Draft"},
+		{Value: forms.NewValue(string(StatusPublished)), Text: "Published"},
+	}
+}
+
+func TestOptionListerEnums(t *testing.T) {
+	type Post struct {
+		Status Status `form:"label:Status"`
+	}
+	fields, err := forms.GenerateFieldsFromStruct(Post{Status: StatusPublished})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var field = fields[0]
+	if field.Type != forms.TypeSelect || len(field.Options) != 2 {
+		t.Fatalf("Expected a select with 2 options, got %q with %d options", field.Type, len(field.Options))
+	}
+	if field.Options[0].Selected || !field.Options[1].Selected {
+		t.Errorf("Expected the current value to be selected")
+	}
+	if !strings.Contains(field.Field().String(), `<option value="published" selected>Published</option>`) {
+		t.Errorf("Expected the selected option to be rendered, got %s", field.Field())
+	}
+
+	var f = forms.Form{}
+	f.AddFields(field)
+	if f.FillValues(url.Values{"Status": {"archived"}}) {
+		t.Errorf("Expected values outside of the choices to be rejected")
+	}
+	if !f.FillValues(url.Values{"Status": {"draft"}}) {
+		t.Fatalf("Expected a listed choice to be valid, got %v", f.Errors)
+	}
+	var p Post
+	if err := f.ScanStruct(&p); err != nil || p.Status != StatusDraft {
+		t.Errorf("Expected the submitted choice to be scanned back, got %q (%v)", p.Status, err)
+	}
+}
+
+func TestSliceOfStructFields(t *testing.T) {
+	type Address struct {
+		Street string
+	}
+	type Person struct {
+		Addresses []Address `form:"label:Addresses"`
+	}
+	_, err := forms.GenerateFieldsFromStruct(Person{})
+	if err == nil || !strings.Contains(err.Error(), "Addresses") || !strings.Contains(err.Error(), "forms_test.Address") {
+		t.Errorf("Expected a descriptive error for slices of structs, got %v", err)
+	}
+
+	type Tagged struct {
+		Tags  []string  `form:"label:Tags"`
+		Days  []Weekday `form:"label:Days"`
+		Ports []uint16  `form:"label:Ports"`
+	}
+	fields, err := forms.GenerateFieldsFromStruct(Tagged{Tags: []string{"a", "b"}, Days: []Weekday{1}, Ports: []uint16{80}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fields[0].Options) != 2 || fields[0].Options[1].Text != "b" {
+		t.Errorf("Expected string slices to generate options, got %+v", fields[0].Options)
+	}
+	if fields[1].Options[0].Value.String() != "monday" || fields[2].Options[0].Value.String() != "80" {
+		t.Errorf("Expected primitive slices to generate options, got %q and %q", fields[1].Options[0].Value.String(), fields[2].Options[0].Value.String())
+	}
+}
+
+func TestBoolCheckboxRoundTrip(t *testing.T) {
+	type Person struct {
+		Male bool `form:"label:Male"`
+	}
+	fields, err := forms.GenerateFieldsFromStruct(Person{Male: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var html = fields[0].Field().String()
+	if fields[0].Type != forms.TypeCheck || !strings.Contains(html, " checked") || !strings.Contains(html, `value="on"`) {
+		t.Errorf("Expected a checked checkbox with the default value attribute, got %s", html)
+	}
+
+	var f = forms.Form{}
+	f.AddFields(fields[0])
+	var r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(""))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	f.Fill(request.NewRequest(nil, r, nil))
+	if strings.Contains(f.Field("Male").Field().String(), "checked") {
+		t.Errorf("Expected the checkbox to be unchecked after a POST without the key")
+	}
+	var p = Person{Male: true}
+	if err := f.ScanStruct(&p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Male {
+		t.Errorf("Expected an absent checkbox to be scanned as false")
+	}
+
+	f.FillValues(url.Values{"Male": {"on"}})
+	if err := f.ScanStruct(&p); err != nil || !p.Male {
+		t.Errorf("Expected a submitted checkbox to be scanned as true, got %v (%v)", p.Male, err)
+	}
+}
@@ -0,0 +1,106 @@
+package forms
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// The serialized state of a form, see Form.Dump.
+type formState struct {
+	Values map[string][]string `json:"values,omitempty"`
+	Errors map[string][]string `json:"errors,omitempty"`
+}
+
+// Dump serializes the values of all non-file fields and the errors of the form to JSON, keyed by field name.
+//
+// This allows a POST-redirect-GET flow to store a failed submission in a session
+// and to show it again after the redirect, see Restore.
+//
+// The values of sensitive fields, such as passwords, are not serialized.
+// Neither are the values and errors of the CSRF, idempotency and version fields:
+// the form built after the redirect issues its own.
+func (f *Form) Dump() ([]byte, error) {
+	if f == nil {
+		return nil, ErrNilForm
+	}
+	var state = formState{
+		Values: make(map[string][]string, len(f.Fields)),
+	}
+	for _, field := range f.Fields {
+		if field.IsFile() || isComputed(field) || isSensitive(field) || isTokenField(field.GetName()) {
+			continue
+		}
+		if v := field.GetValue(); len(v) > 0 {
+			state.Values[field.GetName()] = append(state.Values[field.GetName()], v...)
+		}
+	}
+	for _, err := range f.Errors {
+		if isTokenField(err.Name) {
+			continue
+		}
+		if state.Errors == nil {
+			state.Errors = make(map[string][]string, len(f.Errors))
+		}
+		state.Errors[err.Name] = append(state.Errors[err.Name], err.FieldErr.Error())
+	}
+	return json.Marshal(state)
+}
+
+// isTokenField reports whether the name is that of the CSRF, idempotency or version field,
+// whose values are issued for every form anew.
+func isTokenField(name string) bool {
+	switch name {
+	case CSRFFieldName, IdempotencyFieldName, VersionFieldName:
+		return true
+	}
+	return false
+}
+
+// Restore restores the values and errors serialized by Dump into the form.
+//
+// Values and errors of fields which the form does not have (anymore) are ignored,
+// errors without a field name are restored as form errors.
+// Like Dump, it leaves sensitive fields and the CSRF, idempotency and version fields untouched.
+func (f *Form) Restore(data []byte) error {
+	if f == nil {
+		return ErrNilForm
+	}
+	var state formState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	for _, field := range f.Fields {
+		if field.IsFile() || isSensitive(field) || isTokenField(field.GetName()) {
+			continue
+		}
+		if v := lookup(state.Values, field.GetName(), f.CaseSensitive); v != nil {
+			field.SetValue(v)
+		}
+	}
+	var names = make([]string, 0, len(state.Errors))
+	for name := range state.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var messages = state.Errors[name]
+		var field FormElement
+		if isTokenField(name) {
+			continue
+		}
+		if name != "" {
+			if field = f.Field(name); field == nil {
+				continue
+			}
+		}
+		for _, msg := range messages {
+			var err = errors.New(msg)
+			f.AddError(name, err)
+			if field != nil {
+				field.AddError(err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package forms_test
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestDumpRestore(t *testing.T) {
+	var f = &forms.Form{}
+	f.AddTextField("username", forms.WithRequired())
+	f.AddEmailField("email")
+	f.AddSelectField("tags", forms.WithMultiple())
+	if f.FillValues(url.Values{"email": {"not-an-email"}, "tags": {"a", "b"}}) {
+		t.Fatal("Expected the form to be invalid")
+	}
+	f.AddError("", errors.New("try again later"))
+	data, err := f.Dump()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The restored form no longer has the tags field, but gained a new one.
+	var restored = &forms.Form{ErrorClass: "is-invalid"}
+	restored.AddTextField("username", forms.WithRequired())
+	restored.AddEmailField("email")
+	restored.AddTextField("nickname")
+	if err := restored.Restore(data); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Get("email").String() != "not-an-email" || len(restored.Get("nickname").Value()) != 0 {
+		t.Errorf("Expected the values to be restored, got %v", restored.Get("email"))
+	}
+	if len(restored.Errors) != 3 || !restored.Field("username").HasError() || !restored.Field("email").HasError() {
+		t.Errorf("Expected the errors to be restored, got %v", restored.Errors)
+	}
+	var html = string(restored.AsP())
+	if !strings.Contains(html, `value="not-an-email" class="is-invalid"`) || !strings.Contains(html, `name="username" class="is-invalid" required`) {
+		t.Errorf("Expected the restored values and errors to be rendered, got \n%s", html)
+	}
+
+	if err := restored.Restore([]byte("{")); err == nil {
+		t.Errorf("Expected invalid JSON to return an error")
+	}
+}
+
+func TestDumpRestoreSkipsSecrets(t *testing.T) {
+	var f = &forms.Form{}
+	f.AddTextField("username", forms.WithRequired())
+	f.AddPasswordField("pw")
+	f.CSRFToken("old-token")
+	if f.Fill(newPostRequest(url.Values{"pw": {"hunter2"}, "csrf_token": {"old-token"}})) {
+		t.Fatal("Expected the form to be invalid")
+	}
+	data, err := f.Dump()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "hunter2") || strings.Contains(string(data), "old-token") {
+		t.Errorf("Expected the password and the CSRF token not to be dumped, got %s", data)
+	}
+
+	var restored = &forms.Form{}
+	restored.AddTextField("username", forms.WithRequired())
+	restored.AddPasswordField("pw")
+	restored.CSRFToken("new-token")
+	if err := restored.Restore([]byte(`{"values":{"pw":["hunter2"],"csrf_token":["old-token"]}}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := restored.Restore(data); err != nil {
+		t.Fatal(err)
+	}
+	if html := string(restored.AsP()); !strings.Contains(html, `value="new-token"`) || strings.Contains(html, "hunter2") {
+		t.Errorf("Expected the rotated token to be rendered without the password, got \n%s", html)
+	}
+
+	if !restored.Fill(newPostRequest(url.Values{"username": {"john"}, "csrf_token": restored.Field(forms.CSRFFieldName).GetValue()})) {
+		t.Errorf("Expected the resubmitted form to be valid, got %v", restored.FillError)
+	}
+}
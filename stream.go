@@ -0,0 +1,223 @@
+package forms
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	"github.com/Nigel2392/router/v3/request"
+)
+
+// The default maximum size of a non-file part read by FillMultipartStream.
+var DefaultMaxValueSize int64 = 1 << 20
+
+// The default maximum size and number of all non-file parts read by FillMultipartStream.
+var (
+	DefaultMaxValuesSize int64 = 10 << 20
+	DefaultMaxValueParts       = 1000
+)
+
+// ErrNoFile is returned when a FileSink returns neither a file nor an error.
+var ErrNoFile = errors.New("file sink returned no file")
+
+// A FileSink stores uploaded files while a multipart body is being streamed.
+//
+// The returned reader is set on the file field, it must not be nil.
+type FileSink interface {
+	Store(field, filename string, r io.Reader) (io.ReadSeekCloser, error)
+}
+
+// FileSinkFunc allows a plain function to be used as a FileSink.
+type FileSinkFunc func(field, filename string, r io.Reader) (io.ReadSeekCloser, error)
+
+func (fn FileSinkFunc) Store(field, filename string, r io.Reader) (io.ReadSeekCloser, error) {
+	return fn(field, filename, r)
+}
+
+// MemorySink buffers uploaded files in memory.
+var MemorySink FileSink = FileSinkFunc(func(field, filename string, r io.Reader) (io.ReadSeekCloser, error) {
+	var b, err = io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return nopCloser{bytes.NewReader(b)}, nil
+})
+
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error { return nil }
+
+// FillMultipartStream fills the form by walking the multipart body part by part,
+// without parsing the whole body into memory or temporary files first.
+//
+// Value parts are set on their fields, file parts are handed to the form's FileSink.
+// Value parts larger than MaxValueSize, or more or larger value parts in total than
+// MaxValueParts and MaxValuesSize allow, result in a FillError.
+//
+// The CSRF token is verified before the first file is handed to the FileSink,
+// so the token must precede the file inputs in the form, as it does when rendered by the form.
+//
+// If a later part fails, e.g. by exceeding a limit, the files already stored are closed and removed from their fields.
+// Otherwise the files are left open on their fields, closing them is up to the caller.
+//
+// The form is then validated the same way as with Fill.
+func (f *Form) FillMultipartStream(r *http.Request) (valid bool) {
+	if f == nil {
+		return false
+	}
+	var start = f.fillStarted()
+	defer func() { f.observe(start, valid) }()
+	f.resetFill()
+	var values, err = f.streamMultipart(r)
+	if errors.Is(err, ErrCSRF) {
+		f.FillError = err
+		f.AddError(CSRFFieldName, err)
+		return false
+	}
+	if err != nil {
+		f.FillError = fmt.Errorf("%w: %w", ErrFill, err)
+		f.AddError("Form", f.FillError)
+		return false
+	}
+	return f.afterFill(r.Context(), &request.Request{Request: r}, values.Get(StepFieldName))
+}
+
+func (f *Form) streamMultipart(r *http.Request) (_ url.Values, err error) {
+	var reader *multipart.Reader
+	if reader, err = r.MultipartReader(); err != nil {
+		return nil, err
+	}
+	var sink = f.FileSink
+	if sink == nil {
+		sink = MemorySink
+	}
+	var maxSize = f.MaxValueSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxValueSize
+	}
+	var remaining = f.MaxValuesSize
+	if remaining <= 0 {
+		remaining = DefaultMaxValuesSize
+	}
+	var maxParts = f.MaxValueParts
+	if maxParts <= 0 {
+		maxParts = DefaultMaxValueParts
+	}
+
+	var values = make(url.Values)
+	var parts = 0
+	var csrfVerified = false
+	var seenFiles = make(map[string]bool)
+	var stored = make(map[FormElement]io.ReadSeekCloser)
+	defer func() {
+		if err == nil {
+			return
+		}
+		for field, file := range stored {
+			file.Close()
+			field.Clear()
+		}
+	}()
+	for {
+		var part, err = reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var name = part.FormName()
+		var field = f.Field(name)
+		if part.FileName() == "" {
+			if parts++; parts > maxParts {
+				part.Close()
+				return nil, fmt.Errorf("the body has more than %d value parts", maxParts)
+			}
+			var limit = maxSize
+			if remaining < limit {
+				limit = remaining
+			}
+			var b bytes.Buffer
+			var n, err = io.Copy(&b, io.LimitReader(part, limit+1))
+			part.Close()
+			if err != nil {
+				return nil, err
+			}
+			if n > maxSize {
+				return nil, fmt.Errorf("part %q exceeds the maximum size of %d bytes", name, maxSize)
+			}
+			if n > remaining {
+				return nil, fmt.Errorf("the value parts exceed the maximum total size of %d bytes", remaining)
+			}
+			remaining -= n
+			values[name] = append(values[name], b.String())
+			continue
+		}
+		if field == nil || !field.IsFile() || seenFiles[name] {
+			part.Close()
+			continue
+		}
+		if !csrfVerified {
+			if err := f.verifyCSRF(r, values); err != nil {
+				part.Close()
+				return nil, err
+			}
+			csrfVerified = true
+		}
+		seenFiles[name] = true
+		var max = maxFileSize(field)
+		var src io.Reader = part
+		if max > 0 {
+			src = io.LimitReader(part, max+1)
+		}
+		file, err := sink.Store(name, SanitizeFilename(part.FileName()), src)
+		part.Close()
+		if err != nil {
+			return nil, err
+		}
+		if file == nil {
+			return nil, fmt.Errorf("%w for %q", ErrNoFile, name)
+		}
+		if max > 0 {
+			var size, err = file.Seek(0, io.SeekEnd)
+			if err == nil && size > max {
+				file.Close()
+				f.rejectFile(field, fileTooLarge(max))
+				continue
+			}
+			if err == nil {
+				_, err = file.Seek(0, io.SeekStart)
+			}
+			if err != nil {
+				file.Close()
+				return nil, err
+			}
+		}
+		if err = field.SetFile(submittedFilename(part.Header, part.FileName()), file); err != nil {
+			file.Close()
+			return nil, err
+		}
+		stored[field] = file
+		f.runFileHook(field, SanitizeFilename(part.FileName()), file)
+	}
+
+	if !csrfVerified {
+		if err := f.verifyCSRF(r, values); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, field := range f.Fields {
+		if field.IsFile() {
+			continue
+		}
+		f.fill(field, f.submitted(field, values))
+	}
+	return values, nil
+}
@@ -0,0 +1,145 @@
+package forms_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+// newPhotoRequest returns a multipart request with a title and a photo upload.
+func newPhotoRequest() *http.Request {
+	return newMultipartRequest(func(w *multipart.Writer) {
+		w.WriteField("title", "Holiday")
+		var part, _ = w.CreateFormFile("upload", "photo.txt")
+		part.Write([]byte("file contents"))
+	})
+}
+
+// newTokenRequest returns a multipart request with a photo upload and a CSRF token before or after it.
+func newTokenRequest(tokenFirst bool) *http.Request {
+	return newMultipartRequest(func(w *multipart.Writer) {
+		if tokenFirst {
+			w.WriteField(forms.CSRFFieldName, "token")
+		}
+		var part, _ = w.CreateFormFile("upload", "photo.txt")
+		part.Write([]byte("file contents"))
+		if !tokenFirst {
+			w.WriteField(forms.CSRFFieldName, "token")
+		}
+	})
+}
+
+func TestFillMultipartStream(t *testing.T) {
+	var stored = make(map[string]string)
+	var f = forms.Form{}
+	f.TextField("title", "title", "", "", "").Required = true
+	f.FileField("upload", "upload", "", "", "")
+	f.FileSink = forms.FileSinkFunc(func(field, filename string, r io.Reader) (io.ReadSeekCloser, error) {
+		var b, err = io.ReadAll(r)
+		stored[field] = filename + ":" + string(b)
+		return nopReadSeekCloser{bytes.NewReader(b)}, err
+	})
+	if !f.FillMultipartStream(newPhotoRequest()) {
+		t.Fatalf("Expected the streamed form to be valid, got %s", f.Errors)
+	}
+	if f.Get("title").String() != "Holiday" {
+		t.Errorf("Expected title to be Holiday, got %s", f.Get("title").String())
+	}
+	if stored["upload"] != "photo.txt:file contents" {
+		t.Errorf("Expected the file to be handed to the sink, got %q", stored["upload"])
+	}
+
+	f.FileSink = forms.FileSinkFunc(func(field, filename string, r io.Reader) (io.ReadSeekCloser, error) {
+		return nil, nil
+	})
+	if f.FillMultipartStream(newPhotoRequest()) || !errors.Is(f.FillError, forms.ErrNoFile) {
+		t.Errorf("Expected a sink returning no file to fail, got %v", f.FillError)
+	}
+
+	f.FileSink = nil
+	f.MaxValueSize = 3
+	if f.FillMultipartStream(newPhotoRequest()) || !errors.Is(f.FillError, forms.ErrFill) {
+		t.Errorf("Expected value parts exceeding MaxValueSize to fail, got %v", f.FillError)
+	}
+	f.MaxValueSize = 0
+	f.MaxValueParts = 1
+	if !f.FillMultipartStream(newPhotoRequest()) {
+		t.Errorf("Expected a single value part to be allowed, got %v", f.FillError)
+	}
+	f.MaxValueParts = 0
+	f.MaxValuesSize = 3
+	if f.FillMultipartStream(newPhotoRequest()) || !errors.Is(f.FillError, forms.ErrFill) {
+		t.Errorf("Expected value parts exceeding MaxValuesSize to fail, got %v", f.FillError)
+	}
+}
+
+func TestFillMultipartStreamLimits(t *testing.T) {
+	var r = newMultipartRequest(func(w *multipart.Writer) {
+		for i := 0; i < 3; i++ {
+			w.WriteField("tag", strconv.Itoa(i))
+		}
+	})
+
+	var f = forms.Form{MaxValueParts: 2}
+	f.TextField("tag", "tag", "", "", "")
+	if f.FillMultipartStream(r) || f.FillError == nil || !strings.Contains(f.FillError.Error(), "more than 2 value parts") {
+		t.Errorf("Expected too many value parts to fail, got %v", f.FillError)
+	}
+}
+
+func TestFillMultipartStreamCSRF(t *testing.T) {
+	var stored int
+	var f = forms.Form{}
+	f.CSRFToken("token")
+	f.FileField("upload", "upload", "", "", "")
+	f.FileSink = forms.FileSinkFunc(func(field, filename string, r io.Reader) (io.ReadSeekCloser, error) {
+		stored++
+		return forms.MemorySink.Store(field, filename, r)
+	})
+	if !f.FillMultipartStream(newTokenRequest(true)) || stored != 1 {
+		t.Fatalf("Expected a token before the file to be accepted, got %v", f.Errors)
+	}
+	if f.FillMultipartStream(newTokenRequest(false)) || !errors.Is(f.FillError, forms.ErrCSRF) || stored != 1 {
+		t.Errorf("Expected the file not to be stored before the token was verified, got %v after %d stores", f.FillError, stored)
+	}
+}
+
+type closeCounter struct {
+	io.ReadSeeker
+	closed *int
+}
+
+func (c closeCounter) Close() error {
+	*c.closed++
+	return nil
+}
+
+func TestFillMultipartStreamClosesFiles(t *testing.T) {
+	var r = newMultipartRequest(func(w *multipart.Writer) {
+		var part, _ = w.CreateFormFile("upload", "photo.txt")
+		part.Write([]byte("file contents"))
+		w.WriteField("title", "too long")
+	})
+
+	var closed int
+	var f = forms.Form{MaxValueSize: 3}
+	f.TextField("title", "title", "", "", "")
+	f.FileField("upload", "upload", "", "", "")
+	f.FileSink = forms.FileSinkFunc(func(field, filename string, r io.Reader) (io.ReadSeekCloser, error) {
+		var b, err = io.ReadAll(r)
+		return closeCounter{bytes.NewReader(b), &closed}, err
+	})
+	if f.FillMultipartStream(r) || !errors.Is(f.FillError, forms.ErrFill) {
+		t.Fatalf("Expected the value part exceeding MaxValueSize to fail, got %v", f.FillError)
+	}
+	if name, file := f.Field("upload").GetFile(); closed != 1 || name != "" || file != nil {
+		t.Errorf("Expected the stored file to be closed and removed from its field, got %d closes and %q", closed, name)
+	}
+}
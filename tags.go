@@ -0,0 +1,325 @@
+package forms
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/Nigel2392/forms/validators"
+)
+
+// A key-value pair parsed from a form struct tag.
+type tagPair struct {
+	key   string
+	value string
+	// The segment as written, for error messages.
+	raw string
+}
+
+// parseTag parses a form struct tag into key-value pairs.
+//
+// Segments are separated by semicolons, the key is separated from the value by the first colon.
+// Values starting with a single or double quote run until the matching quote,
+// and a backslash escapes a following semicolon, colon, quote or backslash.
+// This allows values to contain colons, semicolons and surrounding whitespace:
+//
+//	placeholder:'Enter URL: https://example.com'; regex:^a\;b$
+//
+// Note that inside of a struct tag, the backslash itself must be escaped: `form:"regex:^a\\;b$"`.
+//
+// Keys without a value, such as `required` or `autofocus`, have the value "true".
+func parseTag(tag string) ([]tagPair, error) {
+	var (
+		pairs   = make([]tagPair, 0)
+		runes   = []rune(tag)
+		key     []rune
+		value   []rune
+		literal []bool
+		inValue bool
+		quote   rune
+		start   int
+	)
+
+	var segment = func(end int) string {
+		return strings.TrimSpace(string(runes[start:end]))
+	}
+
+	var flush = func(end int) {
+		var k = strings.TrimSpace(string(key))
+		if k != "" {
+			var pair = tagPair{key: k, value: "true", raw: segment(end)}
+			if inValue {
+				pair.value = trimUnquoted(value, literal)
+			}
+			pairs = append(pairs, pair)
+		}
+		key, value, literal, inValue = nil, nil, nil, false
+	}
+
+	for i := 0; i < len(runes); i++ {
+		var c = runes[i]
+		var lit bool
+		switch {
+		case c == '\\' && i+1 < len(runes) && strings.ContainsRune(`;:'"\`, runes[i+1]):
+			i++
+			c = runes[i]
+			lit = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+				continue
+			}
+			lit = true
+		case (c == '\'' || c == '"') && inValue && strings.TrimSpace(string(value)) == "":
+			quote = c
+			continue
+		case c == ';':
+			flush(i)
+			start = i + 1
+			continue
+		case c == ':' && !inValue:
+			inValue = true
+			continue
+		}
+		if inValue {
+			value = append(value, c)
+			literal = append(literal, lit)
+		} else {
+			key = append(key, c)
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in tag segment %q", segment(len(runes)))
+	}
+	flush(len(runes))
+	return pairs, nil
+}
+
+// trimUnquoted trims whitespace from both ends of the value, except for quoted or escaped whitespace.
+func trimUnquoted(value []rune, literal []bool) string {
+	var start, end = 0, len(value)
+	for start < end && !literal[start] && unicode.IsSpace(value[start]) {
+		start++
+	}
+	for end > start && !literal[end-1] && unicode.IsSpace(value[end-1]) {
+		end--
+	}
+	return string(value[start:end])
+}
+
+// applyTag configures the field for a single key-value pair of a form struct tag.
+func applyTag(f *Field, key, value string) error {
+	switch strings.ToLower(key) {
+	case "type":
+		f.Type = value
+	case "label":
+		f.LabelText = value
+	case "placeholder":
+		f.Placeholder = value
+	case "class":
+		f.Class = value
+	case "required":
+		f.Required = true
+	case "min":
+		var i, err = strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		f.SetMin(i)
+	case "max":
+		var i, err = strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		f.SetMax(i)
+	case "readonly":
+		f.ReadOnly = true
+	case "disabled":
+		f.Disabled = true
+	case "hidden":
+		f.SetHidden(true)
+	case "autofocus":
+		f.Autofocus = true
+	case "spellcheck":
+		var b, err = strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		f.Spellcheck = &b
+	case "inputmode":
+		f.InputMode = value
+	case "group":
+		f.Group = value
+	case "tabindex":
+		var i, err = strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		f.TabIndex = &i
+	case "validate":
+		for _, name := range strings.Split(value, ",") {
+			var v, ok = tagValidators[strings.ToLower(strings.TrimSpace(name))]
+			if !ok {
+				return fmt.Errorf("unknown validator %q, known validators are %s", strings.TrimSpace(name), knownTagValidators())
+			}
+			f.Validators = append(f.Validators, v)
+		}
+	case "oneof":
+		f.Validators = append(f.Validators, validators.OneOf(strings.Split(value, "|")...))
+	case "minval":
+		var n, err = strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		f.Validators = append(f.Validators, validators.MinValue(n))
+	case "maxval":
+		var n, err = strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		f.Validators = append(f.Validators, validators.MaxValue(n))
+	case "minlen":
+		var i, err = strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		f.Validators = append(f.Validators, validators.MinLength(i))
+	case "maxlen":
+		var i, err = strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		f.Validators = append(f.Validators, validators.MaxLength(i))
+	case "regex":
+		var reg, err = validators.CompileRegex(value)
+		if err != nil {
+			return err
+		}
+		if f.Validators == nil {
+			f.Validators = make([]validators.Validator, 0)
+		}
+		f.Validators = append(f.Validators, validators.MatchRegexp(reg, f.Required))
+	default:
+		if fn, ok := tagHandlers.Load(strings.ToLower(key)); ok {
+			return fn.(TagHandler)(f, value)
+		}
+	}
+	return nil
+}
+
+// A TagHandler configures a generated field for a custom key of a form struct tag.
+type TagHandler func(f *Field, value string) error
+
+var tagHandlers sync.Map
+
+// RegisterTagHandler registers a handler for a custom key of form struct tags, such as `form:"currency:EUR"`.
+// Keys are matched case-insensitively, and registering a key twice returns an error.
+//
+// Handlers are only consulted for keys which are not built in, and run in the order the keys appear in the tag.
+// Struct types are parsed once, so register handlers before generating fields, or call ClearStructCache.
+// It is safe for concurrent use.
+func RegisterTagHandler(key string, fn func(f *Field, value string) error) error {
+	key = strings.ToLower(strings.TrimSpace(key))
+	if key == "" || fn == nil {
+		return errors.New("tag handler needs a key and a function")
+	}
+	if _, loaded := tagHandlers.LoadOrStore(key, TagHandler(fn)); loaded {
+		return fmt.Errorf("a tag handler for %q is already registered", key)
+	}
+	return nil
+}
+
+// tagValidators are the validators which can be attached by name with the validate tag key,
+// e.g. `form:"validate:email,notempty"`.
+var tagValidators = map[string]validators.Validator{
+	"email":    validators.Email,
+	"url":      validators.URL,
+	"notempty": validators.NotEmpty,
+	"json":     validators.JSON(0, 0),
+	"hexcolor": validators.HexColor(false),
+}
+
+func knownTagValidators() string {
+	var names = make([]string, 0, len(tagValidators))
+	for name := range tagValidators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+type structConfig struct {
+	tagFallback     string
+	nameMapper      func(reflect.StructField) string
+	includeUntagged bool
+}
+
+// A StructOption configures how fields are generated from a struct.
+type StructOption func(*structConfig)
+
+func newStructConfig(opts []StructOption) structConfig {
+	var cfg structConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithTagFallback names struct fields without a form tag after another tag, such as "json",
+// ignoring its options like ",omitempty". Fields whose fallback tag is "-" or missing are still skipped.
+func WithTagFallback(tag string) StructOption {
+	return func(c *structConfig) {
+		c.tagFallback = tag
+	}
+}
+
+// WithNameMapper names struct fields without a form tag, the field is skipped if it returns "" or "-".
+//
+// It takes precedence over WithTagFallback.
+func WithNameMapper(fn func(reflect.StructField) string) StructOption {
+	return func(c *structConfig) {
+		c.nameMapper = fn
+	}
+}
+
+// IncludeUntagged generates a field named after the Go field for every exported struct field without a form tag,
+// with its type inferred from the kind and its label from the Labeler. Fields tagged form:"-" are still skipped.
+//
+// WithNameMapper and WithTagFallback take precedence, a field without the fallback tag is named after the Go field.
+func IncludeUntagged(include bool) StructOption {
+	return func(c *structConfig) {
+		c.includeUntagged = include
+	}
+}
+
+// fallbackName returns the name of a struct field without a form tag, or "" if it should be skipped.
+func (c structConfig) fallbackName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	var name string
+	switch {
+	case c.nameMapper != nil:
+		name = c.nameMapper(field)
+	case c.tagFallback != "":
+		var tag, ok = field.Tag.Lookup(c.tagFallback)
+		if !ok && !c.includeUntagged {
+			return ""
+		}
+		name, _, _ = strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+	case c.includeUntagged:
+		name = field.Name
+	}
+	if name == "-" {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,281 @@
+package forms_test
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestStructCache(t *testing.T) {
+	forms.ClearStructCache()
+	type Login struct {
+		Username string `form:"label:Username; required:true; regex:^[a-z]+$"`
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := forms.GenerateFieldsFromStruct(Login{Username: "john"}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	first, _ := forms.GenerateFieldsFromStruct(Login{Username: "john"})
+	first[0].LabelText = "Changed"
+	first[0].Validators = nil
+	second, _ := forms.GenerateFieldsFromStruct(&Login{Username: "JOHN"})
+	if second[0].LabelText != "Username" || second[0].Value().String() != "JOHN" {
+		t.Errorf("Expected cached blueprints to be copied, got label %q and value %q", second[0].LabelText, second[0].Value().String())
+	}
+	if second[0].Validate() == nil {
+		t.Errorf("Expected the cached regex validator to reject JOHN")
+	}
+}
+
+func TestStructTagFlags(t *testing.T) {
+	type Profile struct {
+		ID       int    `form:"label:ID; readonly"`
+		Name     string `form:"label:Name"`
+		Secret   string `form:"-"`
+		Token    string `form:"hidden"`
+		Internal string `form:"label:Internal; disabled"`
+	}
+	var p = Profile{ID: 7, Name: "John", Secret: "s", Token: "t", Internal: "i"}
+	fields, err := forms.GenerateFieldsFromStruct(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fields) != 4 {
+		t.Fatalf("Expected the - tagged field to be skipped, got %d fields", len(fields))
+	}
+	var f = forms.Form{}
+	for _, field := range fields {
+		f.AddFields(field)
+	}
+	if !strings.Contains(f.Field("ID").Field().String(), "readonly") || !f.Field("Token").(*forms.Field).IsHidden() {
+		t.Errorf("Expected readonly and hidden flags to be applied")
+	}
+
+	f.FillValues(url.Values{"ID": {"999"}, "Name": {"Jane"}, "Token": {"u"}, "Internal": {"x"}})
+	if err := f.ScanStruct(&p); err != nil {
+		t.Fatal(err)
+	}
+	if p.ID != 7 || p.Internal != "i" || p.Name != "Jane" || p.Token != "u" {
+		t.Errorf("Expected readonly and disabled fields to be left untouched, got %+v", p)
+	}
+	if err := f.ScanStruct(&p, forms.AllowDisabled()); err != nil || p.ID != 999 || p.Internal != "x" {
+		t.Errorf("Expected AllowDisabled to write readonly and disabled fields, got %+v (%v)", p, err)
+	}
+}
+
+func TestStructTagParsing(t *testing.T) {
+	type Link struct {
+		URL   string `form:"label:Website; placeholder:Enter URL: https://example.com; class:'a; b'"`
+		Regex string `form:"label:\"  Spaced, label  \"; regex:^a\\;b$"`
+		Digit string `form:"label:Digit; regex:^\\d+$"`
+	}
+	fields, err := forms.GenerateFieldsFromStruct(Link{Regex: "a;b", Digit: "12"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields[0].Placeholder != "Enter URL: https://example.com" || fields[0].Class != "a; b" {
+		t.Errorf("Expected colons and quoted semicolons to be kept, got %q and %q", fields[0].Placeholder, fields[0].Class)
+	}
+	if fields[1].LabelText != "  Spaced, label  " {
+		t.Errorf("Expected quoted whitespace and commas to be kept, got %q", fields[1].LabelText)
+	}
+	if fields[1].Validate() != nil || fields[2].Validate() != nil {
+		t.Errorf("Expected escaped semicolons and regex escapes to be kept")
+	}
+
+	type Broken struct {
+		Age int `form:"label:Age; max:ten"`
+	}
+	_, err = forms.GenerateFieldsFromStruct(Broken{})
+	if err == nil || !strings.Contains(err.Error(), "Age") || !strings.Contains(err.Error(), `"max:ten"`) {
+		t.Errorf("Expected an error naming the field and segment, got %v", err)
+	}
+	type Unterminated struct {
+		Name string `form:"label:'Name"`
+	}
+	if _, err = forms.GenerateFieldsFromStruct(Unterminated{}); err == nil {
+		t.Errorf("Expected an error for an unterminated quote")
+	}
+}
+
+func TestTagValidators(t *testing.T) {
+	type Signup struct {
+		Email string  `form:"label:Email; validate:email,notempty"`
+		Plan  string  `form:"label:Plan; oneof:free|pro|team"`
+		Seats float64 `form:"label:Seats; minval:1; maxval:50"`
+		Name  string  `form:"label:Name; minlen:2; maxlen:10"`
+	}
+
+	var tests = []struct {
+		values  url.Values
+		invalid []string
+	}{
+		{url.Values{"Email": {"john@example.com"}, "Plan": {"pro"}, "Seats": {"5"}, "Name": {"John"}}, nil},
+		{url.Values{"Email": {"not an address"}, "Plan": {"pro"}, "Seats": {"5"}, "Name": {"John"}}, []string{"Email"}},
+		{url.Values{"Email": {"john@example.com"}, "Plan": {"gold"}, "Seats": {"51"}, "Name": {"J"}}, []string{"Plan", "Seats", "Name"}},
+	}
+	for _, test := range tests {
+		var fields, err = forms.GenerateFieldsFromStruct(Signup{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var f = &forms.Form{}
+		for _, field := range fields {
+			f.AddFields(field)
+		}
+		if valid := f.FillValues(test.values); valid != (len(test.invalid) == 0) || len(f.Errors) != len(test.invalid) {
+			t.Errorf("%v: expected %v to be rejected, got %v", test.values, test.invalid, f.Errors)
+			continue
+		}
+		for i, name := range test.invalid {
+			if f.Errors[i].Name != name {
+				t.Errorf("%v: expected %s to be rejected, got %v", test.values, name, f.Errors)
+			}
+		}
+	}
+
+	type Unknown struct {
+		Email string `form:"validate:email,postcode"`
+	}
+	var _, err = forms.GenerateFieldsFromStruct(Unknown{})
+	if err == nil || !strings.Contains(err.Error(), `unknown validator "postcode"`) || !strings.Contains(err.Error(), "email, hexcolor, json, notempty, url") {
+		t.Errorf("Expected an error listing the known validators, got %v", err)
+	}
+}
+
+func TestRegisterTagHandler(t *testing.T) {
+	var err = forms.RegisterTagHandler("currency", func(f *forms.Field, value string) error {
+		if len(value) != 3 {
+			return fmt.Errorf("invalid currency %q", value)
+		}
+		if f.Attrs == nil {
+			f.Attrs = make(map[string]string)
+		}
+		f.Attrs["data-currency"] = value
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if forms.RegisterTagHandler("Currency", func(*forms.Field, string) error { return nil }) == nil {
+		t.Errorf("Expected registering a key twice to fail")
+	}
+
+	type Invoice struct {
+		Total float64 `form:"label:Total; currency:EUR"`
+	}
+	var fields, _ = forms.GenerateFieldsFromStruct(Invoice{Total: 12.5})
+	if len(fields) != 1 || !strings.Contains(fields[0].Field().String(), `data-currency="EUR"`) {
+		t.Errorf("Expected the handler to set the data-currency attribute, got %v", fields)
+	}
+
+	type Broken struct {
+		Total float64 `form:"label:Total; currency:euro"`
+	}
+	if _, err = forms.GenerateFieldsFromStruct(Broken{}); err == nil || !strings.Contains(err.Error(), `invalid currency "euro"`) {
+		t.Errorf("Expected the handler error to be returned, got %v", err)
+	}
+}
+
+func TestStructTagFallback(t *testing.T) {
+	type Profile struct {
+		FullName string `json:"full_name,omitempty"`
+		Email    string `json:"email"`
+		Age      int    `json:",omitempty"`
+		Secret   string `json:"-"`
+		Nickname string
+		Hidden   string `form:"-" json:"hidden"`
+		Bio      string `form:"label:About you" json:"bio"`
+	}
+	var fields, err = forms.GenerateFieldsFromStruct(Profile{FullName: "John Doe", Age: 30}, forms.WithTagFallback("json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, field := range fields {
+		names = append(names, field.Name)
+	}
+	if strings.Join(names, ",") != "full_name,email,Age,Bio" {
+		t.Errorf("Expected the json names to be used, got %v", names)
+	}
+	if fields[0].Value().String() != "John Doe" || fields[0].LabelText != "Full Name" {
+		t.Errorf("Expected full_name to hold John Doe with the label Full Name, got %q and %q", fields[0].Value().String(), fields[0].LabelText)
+	}
+
+	if fields, _ = forms.GenerateFieldsFromStruct(Profile{}); len(fields) != 1 {
+		t.Errorf("Expected fields without a form tag to be skipped by default, got %d fields", len(fields))
+	}
+
+	var f = &forms.Form{}
+	fields, _ = forms.GenerateFieldsFromStruct(Profile{}, forms.WithNameMapper(func(field reflect.StructField) string {
+		return strings.ToLower(field.Name)
+	}))
+	for _, field := range fields {
+		f.AddFields(field)
+	}
+	if f.Field("nickname") == nil || f.Field("Hidden") != nil || f.Field("hidden") != nil {
+		t.Errorf("Expected the mapped names to be used and form:\"-\" to skip, got %v", f.Fields)
+	}
+
+	f = &forms.Form{}
+	fields, _ = forms.GenerateFieldsFromStruct(Profile{}, forms.WithTagFallback("json"))
+	for _, field := range fields {
+		f.AddFields(field)
+	}
+	f.FillValues(url.Values{"full_name": {"Jane"}, "email": {"jane@example.com"}, "Age": {"41"}})
+	var p Profile
+	if err := f.ScanStruct(&p, forms.WithStructOptions(forms.WithTagFallback("json"))); err != nil || p.FullName != "Jane" || p.Email != "jane@example.com" || p.Age != 41 {
+		t.Errorf("Expected the json-named fields to be scanned, got %+v (%v)", p, err)
+	}
+}
+
+func TestIncludeUntagged(t *testing.T) {
+	type Quick struct {
+		Title     string
+		Count     int
+		Ratio     float64
+		Published bool
+		Tags      []string
+		Internal  string `form:"-"`
+		private   string
+	}
+	var fields, err = forms.GenerateFieldsFromStruct(Quick{Title: "Hello", Tags: []string{"a", "b"}, private: "x"}, forms.IncludeUntagged(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected = []struct{ name, typ, label string }{
+		{"Title", "text", "Title"},
+		{"Count", "number", "Count"},
+		{"Ratio", "number", "Ratio"},
+		{"Published", "checkbox", "Published"},
+		{"Tags", "select", "Tags"},
+	}
+	if len(fields) != len(expected) {
+		t.Fatalf("Expected %d fields, got %d", len(expected), len(fields))
+	}
+	for i, e := range expected {
+		if fields[i].Name != e.name || fields[i].Type != e.typ || fields[i].LabelText != e.label {
+			t.Errorf("Expected field %d to be %s (%s) labeled %q, got %s (%s) labeled %q", i, e.name, e.typ, e.label, fields[i].Name, fields[i].Type, fields[i].LabelText)
+		}
+	}
+	if fields[0].Value().String() != "Hello" || len(fields[4].Options) != 2 {
+		t.Errorf("Expected the values to be generated, got %q and %d options", fields[0].Value().String(), len(fields[4].Options))
+	}
+	if fields, _ = forms.GenerateFieldsFromStruct(Quick{}, forms.IncludeUntagged(false)); len(fields) != 0 {
+		t.Errorf("Expected untagged fields to be skipped, got %d fields", len(fields))
+	}
+}
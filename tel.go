@@ -0,0 +1,114 @@
+package forms
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrPhoneNumber is returned by the built-in phone number normalization for invalid numbers.
+var ErrPhoneNumber = errors.New("not a valid phone number")
+
+// NormalizePhone normalizes the phone numbers submitted to a TelField to E.164, e.g. "+31612345678".
+//
+// Numbers without a country code are read as numbers of region, an ISO 3166 code such as "NL".
+// The built-in implementation only knows the numbering rules of a few regions (US, GB, NL and DE),
+// replace it to plug in a full implementation such as a libphonenumber port.
+var NormalizePhone func(number, region string) (string, error) = normalizePhone
+
+type phoneRegion struct {
+	code string
+	// The prefix dialed before national numbers within the region.
+	trunk string
+	// The length of national numbers, without the trunk prefix.
+	min, max int
+}
+
+var phoneRegions = map[string]phoneRegion{
+	"US": {code: "1", trunk: "1", min: 10, max: 10},
+	"GB": {code: "44", trunk: "0", min: 9, max: 10},
+	"NL": {code: "31", trunk: "0", min: 9, max: 9},
+	"DE": {code: "49", trunk: "0", min: 6, max: 13},
+}
+
+func (r phoneRegion) valid(national string) bool {
+	if len(national) < r.min || len(national) > r.max || strings.HasPrefix(national, r.trunk) {
+		return false
+	}
+	// North American area codes never start with 0 or 1.
+	if r.code == "1" && national[0] < '2' {
+		return false
+	}
+	return true
+}
+
+func normalizePhone(number, region string) (string, error) {
+	var digits strings.Builder
+	var international bool
+	for i, c := range strings.TrimSpace(number) {
+		switch {
+		case c >= '0' && c <= '9':
+			digits.WriteRune(c)
+		case c == '+' && i == 0:
+			international = true
+		case c == ' ' || c == '-' || c == '.' || c == '(' || c == ')' || c == '/':
+		default:
+			return "", ErrPhoneNumber
+		}
+	}
+	var n = digits.String()
+	if !international && strings.HasPrefix(n, "00") {
+		international, n = true, n[2:]
+	}
+
+	if international {
+		for _, r := range phoneRegions {
+			if strings.HasPrefix(n, r.code) {
+				if !r.valid(n[len(r.code):]) {
+					return "", ErrPhoneNumber
+				}
+				return "+" + n, nil
+			}
+		}
+		// E.164 numbers have at most 15 digits.
+		if len(n) < 8 || len(n) > 15 {
+			return "", ErrPhoneNumber
+		}
+		return "+" + n, nil
+	}
+
+	if region == "" {
+		if len(n) < 3 || len(n) > 15 {
+			return "", ErrPhoneNumber
+		}
+		return strings.TrimSpace(number), nil
+	}
+	var r, ok = phoneRegions[strings.ToUpper(region)]
+	if !ok {
+		return "", errors.New("unknown phone number region " + region)
+	}
+	if r.trunk != "" && strings.HasPrefix(n, r.trunk) && len(n)-len(r.trunk) >= r.min {
+		n = n[len(r.trunk):]
+	}
+	if !r.valid(n) {
+		return "", ErrPhoneNumber
+	}
+	return "+" + r.code + n, nil
+}
+
+// TelField adds a telephone number field.
+//
+// Submitted numbers are normalized to E.164 with NormalizePhone, using the field's DefaultRegion
+// for numbers without a country code. Without a DefaultRegion, such numbers are kept as entered.
+// Numbers which cannot be normalized are rejected, and re-rendered as they were submitted.
+func (f *Form) TelField(name string, id string, classes string, placeholder string, value string) *Field {
+	var field = newField(TypeTel, name, id, classes, placeholder, value, WithAutocomplete(AutocompleteTel))
+	field.InputMode = "tel"
+	field.SubmitNormalizer = func(v string) (string, error) {
+		if v == "" {
+			return v, nil
+		}
+		return NormalizePhone(v, field.DefaultRegion)
+	}
+	f.AddFields(field)
+	return field
+}
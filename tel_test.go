@@ -0,0 +1,53 @@
+package forms_test
+
+import (
+	"html/template"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestTelField(t *testing.T) {
+	var tests = []struct {
+		region, number, expected string
+		valid                    bool
+	}{
+		{"NL", "+31 6 12345678", "+31612345678", true},
+		{"NL", "06-12345678", "+31612345678", true},
+		{"NL", "0031 6 1234 5678", "+31612345678", true},
+		{"US", "(555) 123-4567", "+15551234567", true},
+		{"US", "1 555 123 4567", "+15551234567", true},
+		{"GB", "020 7946 0958", "+442079460958", true},
+		{"DE", "030 123456", "+4930123456", true},
+		{"", "+49 30 123456", "+4930123456", true},
+		{"", "+33 1 23 45 67 89", "+33123456789", true},
+		{"", "555 1234", "555 1234", true},
+		{"NL", "+31 6 1234", "+31 6 1234", false},
+		{"US", "(055) 123-4567", "(055) 123-4567", false},
+		{"NL", "06-1234567x", "06-1234567x", false},
+		{"XX", "0612345678", "0612345678", false},
+	}
+	for _, test := range tests {
+		var f = forms.Form{}
+		f.TelField("phone", "", "", "", "").DefaultRegion = test.region
+		var valid = f.FillValues(url.Values{"phone": {test.number}})
+		if valid != test.valid {
+			t.Errorf("%s %q: expected valid to be %v, got %v (%v)", test.region, test.number, test.valid, valid, f.Errors)
+		}
+		var phone string
+		if err := f.Scan(nil, &phone); err != nil || phone != test.expected {
+			t.Errorf("%s %q: expected %q, got %q (%v)", test.region, test.number, test.expected, phone, err)
+		}
+		if !valid && !strings.Contains(f.Field("phone").Field().String(), `value="`+template.HTMLEscapeString(test.number)+`"`) {
+			t.Errorf("%s %q: expected the raw input to be re-rendered, got %s", test.region, test.number, f.Field("phone").Field())
+		}
+	}
+
+	var f = forms.Form{}
+	var field = f.TelField("phone", "", "", "", "")
+	if got := field.Field().String(); got != "<input type=\"tel\" id=\"phone\" name=\"phone\" autocomplete=\"tel\" inputmode=\"tel\">\r\n" {
+		t.Errorf("Unexpected rendering %s", got)
+	}
+}
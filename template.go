@@ -0,0 +1,101 @@
+package forms
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// FuncMap returns functions for rendering a form piece by piece in html/template:
+//
+//	{{ form_open .Form "/signup" "post" }}
+//	{{ form_hidden .Form }}
+//	{{ form_label .Form "email" }} {{ form_field .Form "email" }} {{ form_errors .Form "email" }}
+//	{{ form_close }}
+//
+// form_errors renders the errors of the field with its ErrorRenderer,
+// or all errors of the form when no field name is given.
+// Referencing a field the form does not have fails the template execution.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"form_field":  templateField,
+		"form_label":  templateLabel,
+		"form_errors": templateErrors,
+		"form_hidden": templateHidden,
+		"form_open":   templateOpen,
+		"form_close":  templateClose,
+	}
+}
+
+func templateLookup(f *Form, name string) (FormElement, error) {
+	f.bind()
+	var field = f.Field(name)
+	if field == nil {
+		return nil, fmt.Errorf("form has no field named %q", name)
+	}
+	return field, nil
+}
+
+func templateField(f *Form, name string) (template.HTML, error) {
+	var field, err = templateLookup(f, name)
+	if err != nil {
+		return "", err
+	}
+	return template.HTML(field.Field().String()), nil
+}
+
+func templateLabel(f *Form, name string) (template.HTML, error) {
+	var field, err = templateLookup(f, name)
+	if err != nil || !field.HasLabel() {
+		return "", err
+	}
+	return template.HTML(field.Label().String()), nil
+}
+
+func templateErrors(f *Form, name ...string) (template.HTML, error) {
+	if len(name) > 0 {
+		var field, err = templateLookup(f, name[0])
+		if err != nil {
+			return "", err
+		}
+		return fieldErrors(field), nil
+	}
+	if len(f.Errors) == 0 {
+		return "", nil
+	}
+	return f.Errors.AsUL(), nil
+}
+
+func templateHidden(f *Form) template.HTML {
+	return f.RenderHidden()
+}
+
+// templateOpen renders the opening form tag, the method defaults to post.
+// Forms with a file field are given the multipart enctype.
+func templateOpen(f *Form, action string, method ...string) template.HTML {
+	var m = "post"
+	if len(method) > 0 && method[0] != "" {
+		m = strings.ToLower(method[0])
+	}
+	var b strings.Builder
+	b.WriteString(`<form action="`)
+	b.WriteString(template.HTMLEscapeString(action))
+	b.WriteString(`" method="`)
+	b.WriteString(template.HTMLEscapeString(m))
+	b.WriteString(`"`)
+	for _, field := range f.Fields {
+		if field.IsFile() {
+			b.WriteString(` enctype="multipart/form-data"`)
+			break
+		}
+	}
+	if len(f.HXAttrs) > 0 {
+		b.WriteString(BuildAttrs(f.HXAttrs, nil))
+	}
+	b.WriteString(`>`)
+	return template.HTML(b.String())
+}
+
+func templateClose() template.HTML {
+	return "</form>"
+}
@@ -0,0 +1,43 @@
+package forms_test
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestFuncMap(t *testing.T) {
+	var tmpl = template.Must(template.New("form").Funcs(forms.FuncMap()).Parse(
+		`{{ form_open .Form "/signup" }}{{ form_hidden .Form }}` +
+			`{{ form_label .Form "email" }}{{ form_field .Form "email" }}{{ form_errors .Form "email" }}` +
+			`{{ form_errors .Form }}{{ form_close }}`,
+	))
+
+	var f = &forms.Form{RenderConfig: &forms.RenderConfig{Compact: true}}
+	f.AddHiddenField("next", forms.WithValue("/home"))
+	f.AddEmailField("email", forms.WithRequired(), forms.WithLabel("Email"))
+	f.AddField("avatar", forms.WithType(forms.TypeFile))
+	f.FillValues(map[string][]string{"next": {"/home"}})
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, map[string]any{"Form": f}); err != nil {
+		t.Fatal(err)
+	}
+	var expected = `<form action="/signup" method="post" enctype="multipart/form-data">` +
+		`<input type="hidden" id="next" name="next" value="/home">` +
+		`<label for="email">Email<span class="required">*</span></label>` +
+		`<input type="email" id="email" name="email" required>` +
+		`<ul class="errorlist"><li>Email is required</li></ul>` +
+		"<ul class=\"error\">\r\n<li>email: Email is required</li>\r\n</ul>\r\n" +
+		`</form>`
+	if got := b.String(); got != expected {
+		t.Errorf("Expected \n%q\ngot \n%q", expected, got)
+	}
+
+	tmpl = template.Must(template.New("missing").Funcs(forms.FuncMap()).Parse(`{{ form_field .Form "missing" }}`))
+	if err := tmpl.Execute(&b, map[string]any{"Form": f}); err == nil || !strings.Contains(err.Error(), `no field named "missing"`) {
+		t.Errorf("Expected an error for a missing field, got %v", err)
+	}
+}
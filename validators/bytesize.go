@@ -0,0 +1,104 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// byteUnits are ordered from the largest to the smallest unit, binary before SI.
+var byteUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"PiB", 1 << 50}, {"PB", 1e15},
+	{"TiB", 1 << 40}, {"TB", 1e12},
+	{"GiB", 1 << 30}, {"GB", 1e9},
+	{"MiB", 1 << 20}, {"MB", 1e6},
+	{"KiB", 1 << 10}, {"KB", 1e3},
+	{"B", 1},
+}
+
+// byteSizes maps the lowercased suffixes to their size, a single letter is an SI unit.
+var byteSizes = func() map[string]int64 {
+	var m = map[string]int64{"": 1}
+	for _, u := range byteUnits {
+		m[strings.ToLower(u.suffix)] = u.size
+		if u.size > 1 && !strings.HasSuffix(u.suffix, "iB") {
+			m[strings.ToLower(u.suffix[:1])] = u.size
+		}
+	}
+	return m
+}()
+
+// ParseByteSize parses a size such as "512MB", "2GiB", "1.5 kb" or a bare number of bytes.
+//
+// Both SI (KB, MB, ...) and binary (KiB, MiB, ...) suffixes are supported, matched case-insensitively.
+func ParseByteSize(s string) (int64, error) {
+	var value = strings.TrimSpace(s)
+	var i = strings.IndexFunc(value, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i < 0 {
+		i = len(value)
+	}
+	var number, suffix = value[:i], strings.ToLower(strings.TrimSpace(value[i:]))
+	var size, ok = byteSizes[suffix]
+	if !ok || number == "" {
+		return 0, fmt.Errorf("%q is not a valid size", s)
+	}
+	var n, valid = new(big.Rat).SetString(number)
+	if !valid {
+		return 0, fmt.Errorf("%q is not a valid size", s)
+	}
+	n.Mul(n, new(big.Rat).SetInt64(size))
+	if !n.IsInt() {
+		return 0, fmt.Errorf("%q is not a whole number of bytes", s)
+	}
+	if !n.Num().IsInt64() {
+		return 0, fmt.Errorf("%q is too large", s)
+	}
+	return n.Num().Int64(), nil
+}
+
+// FormatByteSize formats a number of bytes in the largest unit it is a multiple of, up to two decimal places,
+// e.g. 1536 is "1.5KiB" and 512000000 is "512MB".
+func FormatByteSize(n int64) string {
+	for _, u := range byteUnits {
+		if n < u.size || n%u.size*100%u.size != 0 {
+			continue
+		}
+		var whole, frac = n / u.size, n % u.size * 100 / u.size
+		if frac == 0 {
+			return strconv.FormatInt(whole, 10) + u.suffix
+		}
+		return strings.TrimSuffix(fmt.Sprintf("%d.%02d", whole, frac), "0") + u.suffix
+	}
+	return strconv.FormatInt(n, 10) + "B"
+}
+
+type byteSize struct {
+	min, max int64
+}
+
+func (v byteSize) Validate(s FormValue) error {
+	var value = s.String()
+	if value == "" {
+		return nil
+	}
+	var n, err = ParseByteSize(value)
+	if err != nil {
+		return errors.New("value is not a valid size")
+	}
+	if n < v.min {
+		return fmt.Errorf("value must be at least %s", FormatByteSize(v.min))
+	}
+	if v.max > 0 && n > v.max {
+		return fmt.Errorf("value must be at most %s", FormatByteSize(v.max))
+	}
+	return nil
+}
+
+func (v byteSize) Describe() Rule {
+	return Rule{Code: "byte_size", Params: map[string]any{"min": v.min, "max": v.max}}
+}
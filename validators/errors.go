@@ -0,0 +1,25 @@
+package validators
+
+import "errors"
+
+// A ValidationError is a failed validation carrying the code and params of the rule which failed,
+// e.g. to translate the message. The built-in validators return their errors as a ValidationError.
+type ValidationError struct {
+	Code   string
+	Params map[string]any
+	// The error holding the default message.
+	Err error
+}
+
+// NewError returns a ValidationError with the code, params and default message.
+func NewError(code string, params map[string]any, message string) *ValidationError {
+	return &ValidationError{Code: code, Params: params, Err: errors.New(message)}
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,99 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"math"
+)
+
+// ImageOpts are the constraints of an uploaded image, zero values mean no constraint.
+type ImageOpts struct {
+	MaxWidth, MaxHeight int
+	MinWidth, MinHeight int
+	// Width divided by height, e.g. 1 for square avatars, with a tolerance of 1%.
+	AspectRatio float64
+	// The allowed formats, as reported by image.DecodeConfig: "png", "jpeg" and "gif".
+	Formats []string
+}
+
+// Image returns a validator that checks if the uploaded file is an image matching the options.
+//
+// Only the image header is decoded, the file is rewound afterwards so it can still be saved.
+// Fields without a file are not checked.
+func Image(opts ImageOpts) Validator {
+	return Func(imageCheck{opts: opts})
+}
+
+type imageCheck struct {
+	opts ImageOpts
+}
+
+func (v imageCheck) Validate(s FormValue) error {
+	if !s.IsFile() {
+		return nil
+	}
+	var _, file = s.File()
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	var cfg, format, err = image.DecodeConfig(file)
+	if _, seekErr := file.Seek(0, io.SeekStart); seekErr != nil {
+		return seekErr
+	}
+	if err != nil {
+		return errors.New("not a valid image")
+	}
+	var o = v.opts
+	if len(o.Formats) > 0 && !contains(o.Formats, format) {
+		return fmt.Errorf("image format %s is not allowed", format)
+	}
+	if o.MaxWidth > 0 && cfg.Width > o.MaxWidth {
+		return fmt.Errorf("image is wider than %d pixels", o.MaxWidth)
+	}
+	if o.MaxHeight > 0 && cfg.Height > o.MaxHeight {
+		return fmt.Errorf("image is taller than %d pixels", o.MaxHeight)
+	}
+	if o.MinWidth > 0 && cfg.Width < o.MinWidth {
+		return fmt.Errorf("image is narrower than %d pixels", o.MinWidth)
+	}
+	if o.MinHeight > 0 && cfg.Height < o.MinHeight {
+		return fmt.Errorf("image is shorter than %d pixels", o.MinHeight)
+	}
+	if o.AspectRatio > 0 {
+		if cfg.Height == 0 || math.Abs(float64(cfg.Width)/float64(cfg.Height)-o.AspectRatio) > o.AspectRatio*0.01 {
+			return fmt.Errorf("image does not have an aspect ratio of %g", o.AspectRatio)
+		}
+	}
+	return nil
+}
+
+func (v imageCheck) Describe() Rule {
+	var params = map[string]any{}
+	var o = v.opts
+	for k, n := range map[string]int{"max_width": o.MaxWidth, "max_height": o.MaxHeight, "min_width": o.MinWidth, "min_height": o.MinHeight} {
+		if n > 0 {
+			params[k] = n
+		}
+	}
+	if o.AspectRatio > 0 {
+		params["aspect_ratio"] = o.AspectRatio
+	}
+	if len(o.Formats) > 0 {
+		params["formats"] = o.Formats
+	}
+	return Rule{Code: "image", Params: params}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,487 @@
+package validators
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// A Rule describes a validator, e.g. to mirror it in client-side validation.
+type Rule struct {
+	Code   string         `json:"code"`
+	Params map[string]any `json:"params,omitempty"`
+}
+
+// Described is implemented by validators which can describe themselves.
+type Described interface {
+	Describe() Rule
+}
+
+// A Check validates a value, it is adapted to a Validator by Func.
+//
+// The built-in validators are checks which implement Described.
+type Check interface {
+	Validate(FormValue) error
+}
+
+// describeProbe is passed to validators by Describe to retrieve the check of a validator created by Func.
+// It holds no value, so validators not created by Func see an empty submission.
+type describeProbe struct {
+	check Check
+}
+
+func (p *describeProbe) IsFile() bool                      { return false }
+func (p *describeProbe) String() string                    { return "" }
+func (p *describeProbe) Value() []string                   { return nil }
+func (p *describeProbe) File() (string, io.ReadSeekCloser) { return "", nil }
+
+// Func adapts a check to a Validator.
+//
+// If the check implements Described, its rule is available through Describe,
+// and its errors are returned as a ValidationError with the code and params of the rule.
+func Func(c Check) Validator {
+	return func(s FormValue) error {
+		if p, ok := s.(*describeProbe); ok {
+			p.check = c
+			return nil
+		}
+		var err = c.Validate(s)
+		if err == nil {
+			return nil
+		}
+		if d, ok := c.(Described); ok {
+			if _, ok := err.(*ValidationError); !ok {
+				var rule = d.Describe()
+				return &ValidationError{Code: rule.Code, Params: rule.Params, Err: err}
+			}
+		}
+		return err
+	}
+}
+
+// Describe returns the rule of a validator created by Func from a Described check,
+// such as the built-in validators.
+//
+// The validator is called with an empty value to retrieve its check,
+// validators which are not created by Func should not have side effects.
+func Describe(v Validator) (rule Rule, ok bool) {
+	if v == nil {
+		return Rule{}, false
+	}
+	defer func() {
+		if recover() != nil {
+			rule, ok = Rule{}, false
+		}
+	}()
+	var p describeProbe
+	v(&p)
+	if d, ok := p.check.(Described); ok {
+		return d.Describe(), true
+	}
+	return Rule{}, false
+}
+
+type maxLength struct {
+	max int
+}
+
+func (v maxLength) Validate(s FormValue) error {
+	var values = s.Value()
+	if len(values) == 0 {
+		return errors.New("value is required")
+	}
+	if len(values[0]) > v.max {
+		return fmt.Errorf("value is too long")
+	}
+	return nil
+}
+
+func (v maxLength) Describe() Rule {
+	return Rule{Code: "max_length", Params: map[string]any{"max": v.max}}
+}
+
+type minLength struct {
+	min int
+}
+
+func (v minLength) Validate(s FormValue) error {
+	var values = s.Value()
+	if len(values) == 0 {
+		return errors.New("value is required")
+	}
+	if len(values[0]) < v.min {
+		return fmt.Errorf("value is too short")
+	}
+	return nil
+}
+
+func (v minLength) Describe() Rule {
+	return Rule{Code: "min_length", Params: map[string]any{"min": v.min}}
+}
+
+type length struct {
+	min, max int
+}
+
+func (v length) Validate(s FormValue) error {
+	var values = s.Value()
+	if len(values) == 0 {
+		return errors.New("value is required")
+	}
+	if len(values[0]) < v.min {
+		return fmt.Errorf("value is too short")
+	}
+	if len(values[0]) > v.max {
+		return fmt.Errorf("value is too long")
+	}
+	return nil
+}
+
+func (v length) Describe() Rule {
+	return Rule{Code: "length", Params: map[string]any{"min": v.min, "max": v.max}}
+}
+
+type passwordStrength struct {
+	minlen, maxlen int
+	needsSpecial   bool
+}
+
+func (v passwordStrength) Validate(fv FormValue) error {
+	var values = fv.Value()
+	if len(values) == 0 {
+		return errors.New("password is required")
+	}
+	var pw = values[0]
+	if len(pw) < v.minlen {
+		return fmt.Errorf("password is too short")
+	} else if len(pw) > v.maxlen {
+		return fmt.Errorf("password is too long")
+	}
+	var upp_ct int = 0
+	var low_ct int = 0
+	var dig_ct int = 0
+	var spa_ct int = 0
+	for _, c := range pw {
+		if unicode.IsUpper(c) {
+			upp_ct++
+		}
+		if unicode.IsLower(c) {
+			low_ct++
+		}
+		if unicode.IsDigit(c) {
+			dig_ct++
+		}
+		if unicode.IsSpace(c) {
+			spa_ct++
+		}
+	}
+
+	if upp_ct == 0 || upp_ct == len(pw) {
+		return fmt.Errorf("password must contain at least one uppercase letter, and at least one lowercase letter")
+	}
+	if low_ct == 0 || low_ct == len(pw) {
+		return fmt.Errorf("password must contain at least one lowercase letter, and at least one uppercase letter")
+	}
+	if dig_ct == 0 || dig_ct == len(pw) {
+		return fmt.Errorf("password must contain at least one digit, and at least one non-digit")
+	}
+	if spa_ct > 0 {
+		return fmt.Errorf("password must not contain spaces")
+	}
+	if v.needsSpecial {
+		// Require at least one special character
+		if len(fv.Value()) == upp_ct+low_ct+dig_ct {
+			return fmt.Errorf("password must contain at least one special character")
+		}
+	}
+	return nil
+}
+
+func (v passwordStrength) Describe() Rule {
+	return Rule{Code: "password_strength", Params: map[string]any{"min": v.minlen, "max": v.maxlen, "special": v.needsSpecial}}
+}
+
+// lazyRegex compiles its regex once, the first time it is used.
+type lazyRegex struct {
+	source     string
+	canBeEmpty bool
+	once       sync.Once
+	reg        *regexp.Regexp
+}
+
+func (v *lazyRegex) Validate(s FormValue) error {
+	v.once.Do(func() {
+		v.reg = regexp.MustCompile(toRegex(v.source))
+	})
+	return matchRegexp(v.reg, s, v.canBeEmpty)
+}
+
+func (v *lazyRegex) Describe() Rule {
+	return Rule{Code: "regex", Params: map[string]any{"pattern": toRegex(v.source)}}
+}
+
+type compiledRegex struct {
+	reg        *regexp.Regexp
+	canBeEmpty bool
+}
+
+func (v compiledRegex) Validate(s FormValue) error {
+	return matchRegexp(v.reg, s, v.canBeEmpty)
+}
+
+func (v compiledRegex) Describe() Rule {
+	return Rule{Code: "regex", Params: map[string]any{"pattern": v.reg.String()}}
+}
+
+type decimal struct {
+	maxDigits, decimalPlaces int
+}
+
+func (d decimal) Validate(s FormValue) error {
+	var v = s.Value()
+	if len(v) == 0 || v[0] == "" {
+		return nil
+	}
+	var value = trimSign(v[0])
+	var intPart, fracPart, _ = strings.Cut(value, ".")
+	if intPart == "" && fracPart == "" {
+		return errors.New("value is not a valid decimal number")
+	}
+	for _, c := range intPart + fracPart {
+		if c < '0' || c > '9' {
+			return errors.New("value is not a valid decimal number")
+		}
+	}
+	if d.decimalPlaces >= 0 && len(fracPart) > d.decimalPlaces {
+		return fmt.Errorf("value has more than %d decimal places", d.decimalPlaces)
+	}
+	var digits = len(strings.TrimLeft(intPart, "0")) + len(fracPart)
+	if d.maxDigits > 0 && digits > d.maxDigits {
+		return fmt.Errorf("value has more than %d digits", d.maxDigits)
+	}
+	return nil
+}
+
+// trimSign removes a single leading + or - from the value.
+func trimSign(v string) string {
+	if v != "" && (v[0] == '+' || v[0] == '-') {
+		return v[1:]
+	}
+	return v
+}
+
+func (d decimal) Describe() Rule {
+	return Rule{Code: "decimal", Params: map[string]any{"max_digits": d.maxDigits, "decimal_places": d.decimalPlaces}}
+}
+
+type oneOf struct {
+	choices []string
+	allowed map[string]struct{}
+}
+
+func (o oneOf) Validate(s FormValue) error {
+	for _, v := range s.Value() {
+		if v == "" {
+			continue
+		}
+		if _, ok := o.allowed[v]; !ok {
+			return fmt.Errorf("%q is not a valid choice", v)
+		}
+	}
+	return nil
+}
+
+func (o oneOf) Describe() Rule {
+	return Rule{Code: "one_of", Params: map[string]any{"choices": o.choices}}
+}
+
+// parseNumber parses the first value, ok is false for an empty value.
+func parseNumber(s FormValue) (n float64, ok bool, err error) {
+	var v = s.String()
+	if v == "" {
+		return 0, false, nil
+	}
+	n, err = strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, false, errors.New("value is not a valid number")
+	}
+	return n, true, nil
+}
+
+type minValue struct {
+	min float64
+}
+
+func (v minValue) Validate(s FormValue) error {
+	var n, ok, err = parseNumber(s)
+	if err != nil {
+		return err
+	}
+	if ok && n < v.min {
+		return fmt.Errorf("value must be at least %s", strconv.FormatFloat(v.min, 'f', -1, 64))
+	}
+	return nil
+}
+
+func (v minValue) Describe() Rule {
+	return Rule{Code: "min", Params: map[string]any{"min": v.min}}
+}
+
+type maxValue struct {
+	max float64
+}
+
+func (v maxValue) Validate(s FormValue) error {
+	var n, ok, err = parseNumber(s)
+	if err != nil {
+		return err
+	}
+	if ok && n > v.max {
+		return fmt.Errorf("value must be at most %s", strconv.FormatFloat(v.max, 'f', -1, 64))
+	}
+	return nil
+}
+
+func (v maxValue) Describe() Rule {
+	return Rule{Code: "max", Params: map[string]any{"max": v.max}}
+}
+
+type notEmpty struct{}
+
+func (notEmpty) Validate(s FormValue) error {
+	if strings.TrimSpace(s.String()) == "" {
+		return errors.New("value must not be empty")
+	}
+	return nil
+}
+
+func (notEmpty) Describe() Rule {
+	return Rule{Code: "not_empty"}
+}
+
+type hexColor struct {
+	allowShort bool
+}
+
+func (v hexColor) Validate(s FormValue) error {
+	var value = s.String()
+	if value == "" {
+		return nil
+	}
+	if value[0] != '#' || len(value) != 7 && !(v.allowShort && len(value) == 4) {
+		return errors.New("value is not a valid color")
+	}
+	for _, c := range value[1:] {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return errors.New("value is not a valid color")
+		}
+	}
+	return nil
+}
+
+func (v hexColor) Describe() Rule {
+	return Rule{Code: "hex_color", Params: map[string]any{"short": v.allowShort}}
+}
+
+type jsonCheck struct {
+	maxDepth, maxSize int
+	object            bool
+}
+
+func (v jsonCheck) Validate(s FormValue) error {
+	var value = s.String()
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	if v.maxSize > 0 && len(value) > v.maxSize {
+		return fmt.Errorf("value is larger than %d bytes", v.maxSize)
+	}
+	var raw json.RawMessage
+	if err := json.Unmarshal([]byte(value), &raw); err != nil {
+		var syntax *json.SyntaxError
+		if errors.As(err, &syntax) {
+			// The offset is just past the invalid character.
+			var line, col = position(value, syntax.Offset-1)
+			return fmt.Errorf("invalid JSON at line %d, column %d: %s", line, col, syntax.Error())
+		}
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if v.object && raw[0] != '{' {
+		return errors.New("value is not a JSON object")
+	}
+	if v.maxDepth <= 0 {
+		return nil
+	}
+	var dec = json.NewDecoder(strings.NewReader(value))
+	var depth int
+	for {
+		var tok, err = dec.Token()
+		if err != nil {
+			return nil
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+			if depth > v.maxDepth {
+				var line, col = position(value, dec.InputOffset()-1)
+				return fmt.Errorf("JSON is nested deeper than %d levels at line %d, column %d", v.maxDepth, line, col)
+			}
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+}
+
+func (v jsonCheck) Describe() Rule {
+	var code = "json"
+	if v.object {
+		code = "json_object"
+	}
+	return Rule{Code: code, Params: map[string]any{"max_depth": v.maxDepth, "max_size": v.maxSize}}
+}
+
+// position returns the 1-based line and column of the byte offset in s.
+func position(s string, offset int64) (line, col int) {
+	if offset > int64(len(s)) {
+		offset = int64(len(s))
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	var before = s[:offset]
+	line = strings.Count(before, "\n") + 1
+	col = len(before) - strings.LastIndexByte(before, '\n')
+	return line, col
+}
+
+// linesMatch compiles its regex once, the first time it is used.
+type linesMatch struct {
+	source string
+	once   sync.Once
+	reg    *regexp.Regexp
+}
+
+func (v *linesMatch) Validate(s FormValue) error {
+	v.once.Do(func() {
+		v.reg = regexp.MustCompile(toRegex(v.source))
+	})
+	var lines = strings.Split(strings.ReplaceAll(s.String(), "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line != "" && !v.reg.MatchString(line) {
+			return fmt.Errorf("line %d does not match: %s", i+1, line)
+		}
+	}
+	return nil
+}
+
+func (v *linesMatch) Describe() Rule {
+	return Rule{Code: "lines_match", Params: map[string]any{"pattern": toRegex(v.source)}}
+}
@@ -2,11 +2,10 @@ package validators
 
 import (
 	"errors"
-	"fmt"
 	"io"
 	"net/mail"
+	"net/url"
 	"regexp"
-	"unicode"
 )
 
 type FormValue interface {
@@ -16,6 +15,9 @@ type FormValue interface {
 	File() (string, io.ReadSeekCloser)
 }
 
+// A Validator validates a submitted value.
+//
+// Validators created by Func from a Described check can be mirrored by client-side validation, see Describe.
 type Validator func(FormValue) error
 
 func New(validators ...Validator) []Validator {
@@ -24,52 +26,17 @@ func New(validators ...Validator) []Validator {
 
 // MaxLength returns a validator that checks if the length of the string is at most max.
 func MaxLength(max int) Validator {
-	return func(s FormValue) error {
-		var v = s.Value()
-		if len(v) == 0 {
-			return errors.New("value is required")
-		}
-		var value = v[0]
-
-		if len(value) > max {
-			return fmt.Errorf("value is too long")
-		}
-		return nil
-	}
+	return Func(maxLength{max: max})
 }
 
 // MinLength returns a validator that checks if the length of the string is at least min.
 func MinLength(min int) Validator {
-	return func(s FormValue) error {
-		var v = s.Value()
-		if len(v) == 0 {
-			return errors.New("value is required")
-		}
-		var value = v[0]
-
-		if len(value) < min {
-			return fmt.Errorf("value is too short")
-		}
-		return nil
-	}
+	return Func(minLength{min: min})
 }
 
 // Check if the string is at least min and at most max.
 func Length(min, max int) Validator {
-	return func(s FormValue) error {
-		var v = s.Value()
-		if len(v) == 0 {
-			return errors.New("value is required")
-		}
-		var value = v[0]
-		if len(value) < min {
-			return fmt.Errorf("value is too short")
-		}
-		if len(value) > max {
-			return fmt.Errorf("value is too long")
-		}
-		return nil
-	}
+	return Func(length{min: min, max: max})
 }
 
 // Verifies an email is valid.
@@ -83,6 +50,32 @@ func Email(s FormValue) error {
 	return err
 }
 
+// Verifies the value contains something other than whitespace.
+var NotEmpty = Func(notEmpty{})
+
+// Verifies a URL is an absolute http or https URL with a host.
+//
+// Empty values are allowed, use Field.Required to require a value.
+func URL(s FormValue) error {
+	var value = s.String()
+	if value == "" {
+		return nil
+	}
+	var u, err = url.Parse(value)
+	if err != nil || u.Host == "" || u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("value is not a valid URL")
+	}
+	return nil
+}
+
+// HexColor returns a validator that checks if the value is a color in the #rrggbb format,
+// as submitted by color inputs. If allowShort is set, the #rgb format is accepted as well.
+//
+// Empty values are allowed, use Field.Required to require a value.
+func HexColor(allowShort bool) Validator {
+	return Func(hexColor{allowShort: allowShort})
+}
+
 // Checks if:
 // - password is at least minlen characters long
 // - password is at most maxlen characters long
@@ -92,77 +85,113 @@ func Email(s FormValue) error {
 // - password contains at least one digit
 // - password contains at least one non-digit
 // - password does not contain any whitespace
-func PasswordStrength(minlen, maxlen int, needsSpecial bool) func(FormValue) error {
-	return func(fv FormValue) error {
-		var v = fv.Value()
-		if len(v) == 0 {
-			return errors.New("password is required")
-		}
-		var pw = v[0]
-		if len(pw) < minlen {
-			return fmt.Errorf("password is too short")
-		} else if len(pw) > maxlen {
-			return fmt.Errorf("password is too long")
-		}
-		var upp_ct int = 0
-		var low_ct int = 0
-		var dig_ct int = 0
-		var spa_ct int = 0
-		for _, c := range pw {
-			if unicode.IsUpper(c) {
-				upp_ct++
-			}
-			if unicode.IsLower(c) {
-				low_ct++
-			}
-			if unicode.IsDigit(c) {
-				dig_ct++
-			}
-			if unicode.IsSpace(c) {
-				spa_ct++
-			}
-		}
-
-		if upp_ct == 0 || upp_ct == len(pw) {
-			return fmt.Errorf("password must contain at least one uppercase letter, and at least one lowercase letter")
-		}
-		if low_ct == 0 || low_ct == len(pw) {
-			return fmt.Errorf("password must contain at least one lowercase letter, and at least one uppercase letter")
-		}
-		if dig_ct == 0 || dig_ct == len(pw) {
-			return fmt.Errorf("password must contain at least one digit, and at least one non-digit")
-		}
-		if spa_ct > 0 {
-			return fmt.Errorf("password must not contain spaces")
-		}
-		if needsSpecial {
-			// Require at least one special character
-			if len(fv.Value()) == upp_ct+low_ct+dig_ct {
-				return fmt.Errorf("password must contain at least one special character")
-			}
-		}
-		return nil
-	}
+func PasswordStrength(minlen, maxlen int, needsSpecial bool) Validator {
+	return Func(passwordStrength{minlen: minlen, maxlen: maxlen, needsSpecial: needsSpecial})
 }
 
 // Matches regex,
 // Also matches custom strings,
 // Example: Regex("<<email>>")("email") -> errors.New("not a match")
 // Example: Regex("<<float>>")("0.01") -> nil
-func Regex(regex string, canBeEmpty bool) func(value FormValue) error {
-	return func(value FormValue) error {
-		var v = value.Value()
-		if len(v) == 0 {
-			if canBeEmpty {
-				return nil
-			}
-			return errors.New("value is required to match regex")
-		}
-		var reg = regexp.MustCompile(toRegex(regex))
-		var match = reg.MatchString(v[0])
-		if !match {
-			return errors.New("not a match")
+//
+// The regex is compiled once, the first time the validator is called.
+func Regex(regex string, canBeEmpty bool) Validator {
+	return Func(&lazyRegex{source: regex, canBeEmpty: canBeEmpty})
+}
+
+// CompileRegex compiles a regex the same way Regex does, custom strings included.
+func CompileRegex(regex string) (*regexp.Regexp, error) {
+	return regexp.Compile(toRegex(regex))
+}
+
+// MatchRegexp is like Regex, but uses an already compiled regex.
+func MatchRegexp(reg *regexp.Regexp, canBeEmpty bool) Validator {
+	return Func(compiledRegex{reg: reg, canBeEmpty: canBeEmpty})
+}
+
+func matchRegexp(reg *regexp.Regexp, value FormValue, canBeEmpty bool) error {
+	var v = value.Value()
+	if len(v) == 0 {
+		if canBeEmpty {
+			return nil
 		}
-		return nil
+		return errors.New("value is required to match regex")
+	}
+	if !reg.MatchString(v[0]) {
+		return errors.New("not a match")
 	}
+	return nil
+}
+
+// Decimal returns a validator that checks if the value is a decimal number
+// with at most maxDigits digits, of which at most decimalPlaces after the decimal point.
+// The value may start with a single + or - sign.
+//
+// A maxDigits of 0 or less means no limit.
+// A decimalPlaces of 0 only allows whole numbers, a negative decimalPlaces means no limit.
+func Decimal(maxDigits, decimalPlaces int) Validator {
+	return Func(decimal{maxDigits: maxDigits, decimalPlaces: decimalPlaces})
+}
+
+// OneOf returns a validator that checks if every submitted value is one of the given choices.
+//
+// Empty values are allowed, use Field.Required to require a choice.
+func OneOf(choices ...string) Validator {
+	var allowed = make(map[string]struct{}, len(choices))
+	for _, c := range choices {
+		allowed[c] = struct{}{}
+	}
+	return Func(oneOf{choices: choices, allowed: allowed})
+}
+
+// MinValue returns a validator that checks if the value is a number of at least min.
+//
+// Empty values are allowed, use Field.Required to require a value.
+func MinValue(min float64) Validator {
+	return Func(minValue{min: min})
+}
+
+// MaxValue returns a validator that checks if the value is a number of at most max.
+//
+// Empty values are allowed, use Field.Required to require a value.
+func MaxValue(max float64) Validator {
+	return Func(maxValue{max: max})
+}
+
+// JSON returns a validator that checks if the value is valid JSON,
+// nested at most maxDepth arrays and objects deep and at most maxSize bytes long.
+// A maxDepth or maxSize of 0 or less means no limit.
+//
+// Syntax errors report the line and column they occurred at.
+// Empty values are allowed, use Field.Required to require a value.
+func JSON(maxDepth, maxSize int) Validator {
+	return Func(jsonCheck{maxDepth: maxDepth, maxSize: maxSize})
+}
+
+// JSONObject is like JSON, but the value must be a JSON object, not an array or a scalar.
+func JSONObject(maxDepth, maxSize int) Validator {
+	return Func(jsonCheck{maxDepth: maxDepth, maxSize: maxSize, object: true})
+}
+
+// LinesMatch returns a validator that checks if every non-empty line of the value matches the regex,
+// e.g. a textarea holding a list of domains. Custom strings are supported like in Regex.
+//
+// Errors report the number of the first line which does not match.
+// The regex is compiled once, the first time the validator is called.
+func LinesMatch(regex string) Validator {
+	return Func(&linesMatch{source: regex})
+}
+
+// ByteSize returns a validator that checks if the value is a size such as "512MB" or "2GiB",
+// of at least min and at most max bytes. A max of 0 or less means no limit.
+//
+// Empty values are allowed, use Field.Required to require a value.
+func ByteSize(min, max int64) Validator {
+	return Func(byteSize{min: min, max: max})
+}
+
+// RateLimitKey returns the key to rate limit submissions of a field by client IP with,
+// e.g. the RemoteIP of forms.RequestInfoFrom. Wiring it to a limiter is up to the caller.
+func RateLimitKey(ip, field string) string {
+	return field + "@" + ip
 }
@@ -0,0 +1,75 @@
+package forms_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+	"github.com/Nigel2392/forms/validators"
+)
+
+func TestStructuredTextValidators(t *testing.T) {
+	var tests = []struct {
+		validator validators.Validator
+		value     string
+		err       string
+	}{
+		{validators.JSON(0, 0), "", ""},
+		{validators.JSON(0, 0), ` {"a": [1, 2]}`, ""},
+		{validators.JSON(0, 0), "{\n  \"a\": 1,\n}", "invalid JSON at line 3, column 1"},
+		{validators.JSON(0, 0), `{"a": 1} {}`, "line 1, column 10"},
+		{validators.JSON(0, 0), `[1, 2`, "unexpected end of JSON input"},
+		{validators.JSON(2, 0), `[[1]]`, ""},
+		{validators.JSON(2, 0), `[[[1]]]`, "nested deeper than 2 levels at line 1, column 3"},
+		{validators.JSON(0, 8), `{"a": "long"}`, "larger than 8 bytes"},
+		{validators.JSONObject(0, 0), ` {"a": 1}`, ""},
+		{validators.JSONObject(0, 0), `[{"a": 1}]`, "not a JSON object"},
+		{validators.JSONObject(0, 0), `"a"`, "not a JSON object"},
+		{validators.LinesMatch(`^[a-z0-9.-]+\.[a-z]+$`), "example.com\r\n\ngo.dev\n", ""},
+		{validators.LinesMatch(`^[a-z0-9.-]+\.[a-z]+$`), "example.com\nnot a domain", "line 2 does not match: not a domain"},
+	}
+	for _, test := range tests {
+		var err = test.validator(forms.NewValue(test.value))
+		if test.err == "" && err != nil {
+			t.Errorf("Expected %q to be valid, got %v", test.value, err)
+		}
+		if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Errorf("Expected %q to fail with %q, got %v", test.value, test.err, err)
+		}
+	}
+}
+
+func TestImageValidator(t *testing.T) {
+	var avatar = validators.Image(validators.ImageOpts{MaxWidth: 512, MaxHeight: 512, AspectRatio: 1, Formats: []string{"png", "jpeg"}})
+
+	large, err := os.ReadFile("testdata/large.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var value = &forms.FormData{FileName: "large.png", Reader: nopReadSeekCloser{bytes.NewReader(large)}}
+	if err := avatar(value); err == nil || !strings.Contains(err.Error(), "wider than 512") {
+		t.Errorf("Expected oversized images to be rejected, got %v", err)
+	}
+	if pos, _ := value.Reader.Seek(0, io.SeekCurrent); pos != 0 {
+		t.Errorf("Expected the file to be rewound, got offset %d", pos)
+	}
+
+	var small bytes.Buffer
+	if err := png.Encode(&small, image.NewGray(image.Rect(0, 0, 64, 64))); err != nil {
+		t.Fatal(err)
+	}
+	value = &forms.FormData{FileName: "small.png", Reader: nopReadSeekCloser{bytes.NewReader(small.Bytes())}}
+	if err := avatar(value); err != nil {
+		t.Errorf("Expected a square 64x64 png to be valid, got %v", err)
+	}
+
+	value = &forms.FormData{FileName: "fake.png", Reader: nopReadSeekCloser{strings.NewReader("not an image")}}
+	if err := avatar(value); err == nil || err.Error() != "not a valid image" {
+		t.Errorf("Expected non-image data to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package forms
+
+import (
+	"crypto/subtle"
+	"errors"
+)
+
+// ErrStaleForm is the error of the version field when the submitted version differs from the expected one,
+// e.g. because someone else saved the record since the form was rendered.
+var ErrStaleForm = errors.New("the form was modified by someone else")
+
+// The name of the hidden field added by Form.VersionField.
+const VersionFieldName = "_version"
+
+// VersionField adds a hidden field holding the current version of the edited record,
+// such as an ETag or an updated-at timestamp.
+//
+// During validation the submitted version is compared against the expected version,
+// which defaults to currentVersion and can be changed with ExpectVersion.
+// A mismatch adds ErrStaleForm to the errors of the form, see IsStale.
+// The version field is skipped by Values, Scan and ScanStruct.
+func (f *Form) VersionField(currentVersion string) *Field {
+	var field = newField(TypeHidden, VersionFieldName, VersionFieldName, "", "", currentVersion, WithLabel(""), WithAutocomplete(AutocompleteOff))
+	if f == nil {
+		return field
+	}
+	f.AddFields(field)
+	f.versionField = field
+	f.expectedVersion = currentVersion
+	return field
+}
+
+// ExpectVersion sets the version the submitted version must match, e.g. the version of the record as it is stored now.
+func (f *Form) ExpectVersion(version string) *Form {
+	if f == nil {
+		return nil
+	}
+	f.expectedVersion = version
+	return f
+}
+
+// IsStale reports whether the submitted version did not match the expected version.
+func (f *Form) IsStale() bool {
+	if f == nil {
+		return false
+	}
+	for _, err := range f.Errors {
+		if errors.Is(err.FieldErr, ErrStaleForm) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkVersion compares the submitted version against the expected version.
+func (f *Form) checkVersion() error {
+	if f.versionField == nil {
+		return nil
+	}
+	var submitted = f.versionField.Value().String()
+	if subtle.ConstantTimeCompare([]byte(submitted), []byte(f.expectedVersion)) != 1 {
+		return ErrStaleForm
+	}
+	return nil
+}
+
+// isVersionField reports whether the field is the form's version field.
+func (f *Form) isVersionField(field FormElement) bool {
+	var fld, ok = asField(field)
+	return ok && f.versionField != nil && fld == f.versionField
+}
@@ -0,0 +1,44 @@
+package forms_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestVersionField(t *testing.T) {
+	var f = &forms.Form{}
+	f.AddTextField("title", forms.WithRequired())
+	f.VersionField("v1").Required = true
+	f.ExpectVersion("v1")
+
+	var html = string(f.AsP())
+	if !strings.Contains(html, `type="hidden" id="_version" name="_version" value="v1"`) {
+		t.Errorf("Expected the version to be rendered as a hidden field, got %s", html)
+	}
+
+	if !f.FillValues(url.Values{"title": {"Draft"}, "_version": {"v1"}}) || f.IsStale() {
+		t.Fatalf("Expected the current version to be accepted, got %v", f.Errors)
+	}
+	if values, _ := f.Values(); values.Has("_version") {
+		t.Errorf("Expected the version to be excluded from Values, got %v", values)
+	}
+	var title, version = "", "unchanged"
+	if err := f.Scan(nil, &title, &version); err != nil || title != "Draft" || version != "unchanged" {
+		t.Errorf("Expected the version not to be scanned, got %q %q (%v)", title, version, err)
+	}
+
+	// Someone else saved the record as v2 after the form was rendered with v1.
+	f.ExpectVersion("v2")
+	if f.FillValues(url.Values{"title": {""}, "_version": {"v1"}}) || !f.IsStale() {
+		t.Errorf("Expected a stale submission to fail with ErrStaleForm, got %v", f.Errors)
+	}
+	if codes := f.Errors.AsMap(forms.ErrorCodes); len(codes["title"]) != 1 {
+		t.Errorf("Expected the other fields to be validated as well, got %v", codes)
+	}
+	if html := string(f.AsP()); !strings.Contains(html, `value="v1"`) {
+		t.Errorf("Expected the submitted version to be rendered back, got %s", html)
+	}
+}
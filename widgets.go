@@ -0,0 +1,247 @@
+package forms
+
+import (
+	"bytes"
+	"html/template"
+	"strconv"
+)
+
+// WidgetContext is passed to a widget when rendering a field.
+type WidgetContext struct {
+	Field *Field
+	// The value to display, already formatted by the field's DisplayFormatter.
+	Value string
+}
+
+// A Widget renders the input element of a field.
+//
+// Widgets only handle presentation, the field still handles filling and validating its value.
+type Widget interface {
+	Render(ctx WidgetContext) template.HTML
+}
+
+// WidgetFunc adapts a function to the Widget interface.
+type WidgetFunc func(ctx WidgetContext) template.HTML
+
+func (w WidgetFunc) Render(ctx WidgetContext) template.HTML {
+	return w(ctx)
+}
+
+// Built-in widgets, selected by the field's Type unless Field.Widget is set.
+type (
+	// TextInput renders an <input> of the field's type.
+	TextInput struct{}
+	// Textarea renders a <textarea>.
+	Textarea struct{}
+	// Select renders a <select> with the field's options.
+	Select struct{}
+	// RadioSelect renders a radio button with a label for each of the field's options.
+	RadioSelect struct{}
+	// CheckboxInput renders a checkbox or a single radio with Field.CheckValue as its value,
+	// checked by Field.Checked or a submitted CheckValue.
+	CheckboxInput struct{}
+	// FileInput renders a file input, preceded by the name of the current file.
+	FileInput struct{}
+	// Button renders a <button> with the field's ButtonText.
+	Button struct{}
+)
+
+func widgetFor(typ string) Widget {
+	switch typ {
+	case TypeSubmit, TypeReset, TypeButton:
+		return Button{}
+	case TypeFile:
+		return FileInput{}
+	case TypeTextArea:
+		return Textarea{}
+	case TypeCheck, TypeRadio:
+		return CheckboxInput{}
+	case TypeSelect:
+		return Select{}
+	}
+	return TextInput{}
+}
+
+// render writes a widget into a pooled buffer.
+func render(ctx WidgetContext, fn func(b *bytes.Buffer, f *Field, value string)) template.HTML {
+	var b = bufferPool.Get().(*bytes.Buffer)
+	b.Reset()
+	defer bufferPool.Put(b)
+	fn(b, ctx.Field, ctx.Value)
+	return template.HTML(b.String())
+}
+
+func (TextInput) Render(ctx WidgetContext) template.HTML {
+	return render(ctx, func(b *bytes.Buffer, f *Field, value string) {
+		b.WriteString(`<input`)
+		f.writeAttrs(b, value)
+		b.WriteString(">")
+		b.WriteString(f.newline())
+	})
+}
+
+func (Textarea) Render(ctx WidgetContext) template.HTML {
+	return render(ctx, func(b *bytes.Buffer, f *Field, value string) {
+		b.WriteString(`<textarea`)
+		f.writeAttrs(b, value)
+		b.WriteString(`>`)
+		template.HTMLEscape(b, []byte(value))
+		b.WriteString("</textarea>")
+		b.WriteString(f.newline())
+	})
+}
+
+func (Select) Render(ctx WidgetContext) template.HTML {
+	return render(ctx, func(b *bytes.Buffer, f *Field, value string) {
+		b.WriteString(`<select`)
+		f.writeAttrs(b, value)
+		b.WriteString(">")
+		b.WriteString(f.newline())
+		if f.EmptyLabel != "" {
+			b.WriteString(`<option value="" disabled`)
+			if !hasSelection(f) {
+				b.WriteString(` selected`)
+			}
+			b.WriteString(`>`)
+			template.HTMLEscape(b, []byte(f.EmptyLabel))
+			b.WriteString("</option>")
+			b.WriteString(f.newline())
+		}
+		var submitted = hasValue(f)
+		for _, option := range f.Options {
+			b.WriteString(`<option`)
+			writeAttr(b, "value", option.Value.String())
+			if optionSelected(f, option, submitted) {
+				b.WriteString(` selected`)
+			}
+			b.WriteString(`>`)
+			template.HTMLEscape(b, []byte(option.Text))
+			b.WriteString("</option>")
+			b.WriteString(f.newline())
+		}
+		b.WriteString("</select>")
+		b.WriteString(f.newline())
+	})
+}
+
+// hasSelection reports whether any option of the field is selected.
+func hasSelection(f *Field) bool {
+	var submitted = hasValue(f)
+	for _, option := range f.Options {
+		if optionSelected(f, option, submitted) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasValue reports whether the field has a non-empty value.
+func hasValue(f *Field) bool {
+	if f.FormValue == nil {
+		return false
+	}
+	for _, v := range f.FormValue.Val {
+		if v != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// optionSelected reports whether the option is selected.
+//
+// Options follow the field's value when it has one, e.g. after Fill,
+// otherwise the option's Selected flag is used.
+// The integer values of a NumberSelectField match by their canonical form, "07" matches "7".
+func optionSelected(f *Field, option Option, submitted bool) bool {
+	if !submitted {
+		return option.Selected
+	}
+	var value = option.Value.String()
+	for _, v := range f.FormValue.Val {
+		if v == value || f.intOptions && canonicalInt(v) == canonicalInt(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (RadioSelect) Render(ctx WidgetContext) template.HTML {
+	return render(ctx, func(b *bytes.Buffer, f *Field, value string) {
+		var id = f.EffectiveID()
+		b.WriteString(`<div`)
+		writeAttr(b, "id", id)
+		if class := f.class(); class != "" {
+			writeAttr(b, "class", class)
+		}
+		b.WriteString(">")
+		b.WriteString(f.newline())
+		var submitted = hasValue(f)
+		for i, option := range f.Options {
+			var optionID = id + "_" + strconv.Itoa(i)
+			var optionValue = option.Value.String()
+			b.WriteString(`<input type="radio"`)
+			writeAttr(b, "id", optionID)
+			writeAttr(b, "name", f.Name)
+			writeAttr(b, "value", optionValue)
+			if optionSelected(f, option, submitted) {
+				b.WriteString(` checked`)
+			}
+			if f.Disabled {
+				b.WriteString(` disabled`)
+			}
+			if f.Required {
+				b.WriteString(` required`)
+			}
+			b.WriteString(`><label`)
+			writeAttr(b, "for", optionID)
+			b.WriteString(`>`)
+			template.HTMLEscape(b, []byte(option.Text))
+			b.WriteString("</label>")
+			b.WriteString(f.newline())
+		}
+		b.WriteString("</div>")
+		b.WriteString(f.newline())
+	})
+}
+
+func (CheckboxInput) Render(ctx WidgetContext) template.HTML {
+	return render(ctx, func(b *bytes.Buffer, f *Field, value string) {
+		b.WriteString(`<input`)
+		f.writeAttrs(b, f.checkValue())
+		if !f.Checked && f.isChecked() {
+			b.WriteString(` checked`)
+		}
+		b.WriteString(">")
+		b.WriteString(f.newline())
+	})
+}
+
+func (FileInput) Render(ctx WidgetContext) template.HTML {
+	return render(ctx, func(b *bytes.Buffer, f *Field, value string) {
+		if value != "" {
+			b.WriteString(`<p class="form-control">`)
+			b.WriteString(value)
+			b.WriteString(`</p>`)
+		}
+		b.WriteString(`<input`)
+		f.writeAttrs(b, value)
+		b.WriteString(">")
+		b.WriteString(f.newline())
+	})
+}
+
+func (Button) Render(ctx WidgetContext) template.HTML {
+	return render(ctx, func(b *bytes.Buffer, f *Field, value string) {
+		var text = f.ButtonText
+		if text == "" {
+			text = f.LabelText
+		}
+		b.WriteString(`<button`)
+		f.writeAttrs(b, value)
+		b.WriteString(`>`)
+		b.WriteString(text)
+		b.WriteString("</button>")
+		b.WriteString(f.newline())
+	})
+}
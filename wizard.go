@@ -0,0 +1,144 @@
+package forms
+
+import (
+	"html/template"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The name of the hidden field which keeps track of the current step of a multi-step form.
+const StepFieldName = "__step"
+
+// Steps divides the form into steps, each step being a group of field names.
+//
+// When steps are set, Fill validates all fields except those of the steps after the current step,
+// fields which are in no step are always validated.
+//
+// The current step is submitted by the client, so Fill returning true does not mean the form is complete:
+// only act on the submission once IsLastStep reports true as well.
+func (f *Form) Steps(steps [][]string) {
+	if f == nil {
+		return
+	}
+	f.steps = steps
+}
+
+// NumSteps returns the number of steps of the form.
+func (f *Form) NumSteps() int {
+	if f == nil {
+		return 0
+	}
+	return len(f.steps)
+}
+
+// IsLastStep reports whether the current step is the final step of the form.
+func (f *Form) IsLastStep() bool {
+	if f == nil {
+		return true
+	}
+	return f.CurrentStep >= len(f.steps)-1
+}
+
+// ValidateStep validates only the fields of step n.
+//
+// Fields of other steps are left untouched, and will not be added to the form errors.
+// The previous errors of the step's fields are replaced.
+func (f *Form) ValidateStep(n int) bool {
+	if f == nil {
+		return false
+	}
+	var start = time.Now()
+	f.resetErrors(f.stepFields(n))
+	var valid = f.validateFields(f.stepFields(n))
+	f.observe(start, valid)
+	return valid
+}
+
+// RenderStep renders the fields of step n as paragraphs.
+//
+// The values of fields in prior steps are carried forward as hidden inputs,
+// along with the hidden step field.
+//
+// Hidden fields which are in no step, such as the CSRF, idempotency and version fields,
+// are rendered in every step, as they are validated in every step.
+//
+// Sensitive fields, such as passwords, are not carried forward: keep them in the last step,
+// or store their values on the server.
+func (f *Form) RenderStep(n int) template.HTML {
+	if f == nil {
+		return ""
+	}
+	f.bind()
+	var b strings.Builder
+	var newline = f.renderConfig().newline()
+	for i := 0; i < n && i < len(f.steps); i++ {
+		for _, field := range f.stepFields(i) {
+			if field.IsFile() || isSensitive(field) {
+				continue
+			}
+			for _, v := range field.GetValue() {
+				writeHidden(&b, field.GetName(), v, newline)
+			}
+		}
+	}
+	writeHidden(&b, StepFieldName, strconv.Itoa(n), newline)
+	writeHiddenFields(&b, f.fieldsUpToStep(-1))
+	for _, field := range f.stepFields(n) {
+		writeP(&b, field)
+	}
+	return template.HTML(b.String())
+}
+
+func (f *Form) stepFields(n int) []FormElement {
+	if n < 0 || n >= len(f.steps) {
+		return []FormElement{}
+	}
+	var fields = make([]FormElement, 0, len(f.steps[n]))
+	for _, name := range f.steps[n] {
+		var field = f.Field(name)
+		if field != nil {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// fieldsUpToStep returns all fields of the form except those of the steps after step n,
+// step -1 returns the fields which are in no step.
+func (f *Form) fieldsUpToStep(n int) []FormElement {
+	var later = make(map[string]bool)
+	for i := n + 1; i < len(f.steps); i++ {
+		for _, field := range f.stepFields(i) {
+			later[field.GetName()] = true
+		}
+	}
+	var fields = make([]FormElement, 0, len(f.Fields))
+	for _, field := range f.Fields {
+		if !later[field.GetName()] {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// Parse the submitted step, clamping it to the available steps.
+func (f *Form) stepFromRequest(value string) int {
+	var step, err = strconv.Atoi(value)
+	if err != nil || step < 0 {
+		return 0
+	}
+	if step >= len(f.steps) {
+		return len(f.steps) - 1
+	}
+	return step
+}
+
+func writeHidden(b *strings.Builder, name, value, newline string) {
+	b.WriteString(`<input type="hidden" name="`)
+	b.WriteString(template.HTMLEscapeString(name))
+	b.WriteString(`" value="`)
+	b.WriteString(template.HTMLEscapeString(value))
+	b.WriteString(`">`)
+	b.WriteString(newline)
+}
@@ -0,0 +1,93 @@
+package forms_test
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Nigel2392/forms"
+)
+
+func TestWizardSteps(t *testing.T) {
+	var f = &forms.Form{}
+	f.TextField("username", "username", "", "", "").Required = true
+	f.EmailField("email", "email", "", "", "").Required = true
+	f.PasswordField("password", "password", "", "", "").Required = true
+	f.Steps([][]string{{"username", "email"}, {"password"}})
+	if !f.Fill(newPostRequest(url.Values{"username": {"john"}, "email": {"john@example.com"}, forms.StepFieldName: {"0"}})) {
+		t.Fatalf("Expected first step to be valid, got %s", f.Errors)
+	}
+	if f.Field("password").HasError() {
+		t.Errorf("Expected later steps not to be validated")
+	}
+
+	var rendered = string(f.RenderStep(1))
+	for _, want := range []string{
+		`<input type="hidden" name="username" value="john">`,
+		`<input type="hidden" name="email" value="john@example.com">`,
+		`<input type="hidden" name="__step" value="1">`,
+		`name="password"`,
+	} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("Expected %q in rendered step, got %s", want, rendered)
+		}
+	}
+
+	if f.Fill(newPostRequest(url.Values{"username": {"john"}, "email": {"john@example.com"}, forms.StepFieldName: {"1"}})) {
+		t.Errorf("Expected second step to require a password")
+	}
+	if f.CurrentStep != 1 || len(f.Errors) != 1 {
+		t.Errorf("Expected one error on step 1, got step %d with errors %s", f.CurrentStep, f.Errors)
+	}
+
+	// The step is chosen by the client, a valid early step is not a complete form.
+	if !f.Fill(newPostRequest(url.Values{"username": {"john"}, "email": {"john@example.com"}, forms.StepFieldName: {"0"}})) || f.IsLastStep() {
+		t.Errorf("Expected step 0 to be valid but not the last step")
+	}
+
+	// Fields outside of the steps are always validated.
+	f.TextField("terms", "terms", "", "", "").Required = true
+	if f.Fill(newPostRequest(url.Values{"username": {"john"}, "email": {"john@example.com"}, "password": {"pw"}, forms.StepFieldName: {"1"}})) {
+		t.Errorf("Expected a required field outside of the steps to be validated")
+	}
+}
+
+func TestWizardSensitiveFields(t *testing.T) {
+	var f = &forms.Form{}
+	f.PasswordField("password", "password", "", "", "")
+	f.TextField("username", "username", "", "", "")
+	f.TextField("bio", "bio", "", "", "")
+	f.Steps([][]string{{"username", "password"}, {"bio"}})
+	f.Fill(newPostRequest(url.Values{"username": {"john"}, "password": {"s3cret"}, forms.StepFieldName: {"0"}}))
+	var rendered = string(f.RenderStep(1))
+	if strings.Contains(rendered, "s3cret") || !strings.Contains(rendered, `name="username" value="john"`) {
+		t.Errorf("Expected only non-sensitive values to be carried forward, got %s", rendered)
+	}
+}
+
+func TestWizardCSRF(t *testing.T) {
+	var f = &forms.Form{}
+	f.TextField("username", "username", "", "", "").Required = true
+	f.EmailField("email", "email", "", "", "").Required = true
+	f.PasswordField("password", "password", "", "", "").Required = true
+	f.Steps([][]string{{"username", "email"}, {"password"}})
+	f.CSRFToken("secret")
+	for step, values := range []url.Values{
+		{"username": {"john"}, "email": {"john@example.com"}},
+		{"username": {"john"}, "email": {"john@example.com"}, "password": {"pw"}},
+	} {
+		var rendered = string(f.RenderStep(step))
+		if !strings.Contains(rendered, `name="csrf_token" value="secret"`) {
+			t.Fatalf("Expected step %d to render the CSRF token, got %s", step, rendered)
+		}
+		values.Set(forms.CSRFFieldName, "secret")
+		values.Set(forms.StepFieldName, strconv.Itoa(step))
+		if !f.Fill(newPostRequest(values)) {
+			t.Fatalf("Expected step %d to be valid, got %v (%s)", step, f.FillError, f.Errors)
+		}
+	}
+	if !f.IsLastStep() {
+		t.Errorf("Expected the wizard to be complete")
+	}
+}